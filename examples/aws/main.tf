@@ -8,12 +8,9 @@ terraform {
 
 provider "gitbook" {}
 
-# You can find your organization ID by visiting https://app.gitbook.com opening
-# the settings page of the organization you installed the GitBook Terraform
-# integration for. The organization settings page has a "Copy org ID" button.
-variable "organization_id" {
-  type = string
-}
+# The organization you installed the GitBook Terraform integration for. Set
+# `id` to look up another organization that the access token can access.
+data "gitbook_organization" "current" {}
 
 # In a real-world scenario, you would use actual resources from the `aws`
 # provider, but for the sake of this example, we'll use some fake static data.
@@ -24,58 +21,55 @@ locals {
 }
 
 resource "gitbook_entity_schema" "aws_account" {
-  organization_id = var.organization_id
+  organization_id = data.gitbook_organization.current.id
   title = {
     singular : "AWS Account"
     plural : "AWS Accounts",
   }
   type = "terraform:aws-account"
-  properties = [
-    {
-      "name" : "arn",
-      "title" : "ARN",
-      "type" : "text"
-    },
-    {
-      "name" : "id",
-      "title" : "ID",
-      "type" : "text"
-    },
-    {
-      "name" : "email",
-      "title" : "Email",
-      "type" : "text"
+  properties = {
+    arn = {
+      title = "ARN"
+      type  = "text"
     }
-  ]
+    id = {
+      title = "ID"
+      type  = "text"
+    }
+    email = {
+      title = "Email"
+      type  = "text"
+    }
+  }
 }
 
 resource "gitbook_entity_schema" "aws_lambda_function" {
-  organization_id = var.organization_id
+  organization_id = data.gitbook_organization.current.id
   type            = "terraform:aws-lambda-function"
   title = {
     singular = "AWS Lambda Function"
     plural   = "AWS Lambda Functions",
   }
-  properties = [
-    {
-      "name" : "arn",
-      "title" : "ARN",
-      "type" : "text"
-    },
-    {
-      "entity" : {
-        "type" : gitbook_entity_schema.aws_account.type
-      },
-      "name" : "account",
-      "title" : "Account",
-      "type" : "relation"
-    },
-    {
-      "name" : "region",
-      "title" : "Region",
-      "type" : "text"
+  properties = {
+    arn = {
+      title = "ARN"
+      type  = "text"
+      order = 1
     }
-  ]
+    account = {
+      title = "Account"
+      type  = "relation"
+      order = 2
+      entity = {
+        type = gitbook_entity_schema.aws_account.type
+      }
+    }
+    region = {
+      title = "Region"
+      type  = "text"
+      order = 3
+    }
+  }
 }
 
 resource "gitbook_entity" "example_aws_lambda_function" {
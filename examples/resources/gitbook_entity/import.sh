@@ -0,0 +1,3 @@
+# Entities can be imported using the organization ID, the entity schema type and
+# the entity ID, separated by slashes. The entity ID may itself contain slashes.
+terraform import gitbook_entity.example "4Me7JapjYF3sgxrFoKxP/terraform:example/example-id"
@@ -0,0 +1,3 @@
+# Entity schemas can be imported using the organization ID and the entity schema
+# type, separated by a slash.
+terraform import gitbook_entity_schema.example_entity_schema "4Me7JapjYF3sgxrFoKxP/terraform:example"
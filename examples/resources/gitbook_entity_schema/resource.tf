@@ -5,21 +5,40 @@ resource "gitbook_entity_schema" "example_entity_schema" {
     "singular" : "Example",
     "plural" : "Examples"
   }
-  properties = [
-    {
-      "name" : "name",
-      "title" : "Name",
-      "type" : "text",
-    },
-    {
-      "name" : "age",
-      "title" : "Age",
-      "type" : "number",
-    },
-    {
-      "name" : "subscribed",
-      "title" : "Subscribed",
-      "type" : "boolean",
+  properties = {
+    name = {
+      title = "Name"
+      type  = "text"
+      order = 1 # Properties are displayed in ascending `order`
     }
-  ]
+    age = {
+      title = "Age"
+      type  = "number"
+      order = 2
+    }
+    subscribed = {
+      title = "Subscribed"
+      type  = "boolean"
+      order = 3
+    }
+  }
+}
+
+# Properties can also be defined from a JSON Schema document. They are ordered
+# as they appear in the document, which `jsonencode` sorts by name.
+resource "gitbook_entity_schema" "example_json_entity_schema" {
+  organization_id = "4Me7JapjYF3sgxrFoKxP"
+  type            = "terraform:example_json"
+  title = {
+    "singular" : "JSON example",
+    "plural" : "JSON examples"
+  }
+  json_schema = jsonencode({
+    type = "object"
+    properties = {
+      name       = { type = "string", title = "Name" }
+      created_at = { type = "string", format = "date-time", title = "Created at" }
+      example    = { "$ref" = "terraform:example", title = "Example" }
+    }
+  })
 }
@@ -0,0 +1,2 @@
+# Spaces can be imported using the space ID.
+terraform import gitbook_space.example_space "Wcw2T7uw6UyvMtQT6vNW"
@@ -0,0 +1,7 @@
+resource "gitbook_space" "example_space" {
+  organization_id = "4Me7JapjYF3sgxrFoKxP" # Typically you would reference a variable
+  title           = "Example"
+  emoji           = "1f4d6"
+  visibility      = "private"
+  parent          = "n3hGDEyvV8Gzv2EwCHXe" # The ID of a collection, optional
+}
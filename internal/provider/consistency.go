@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// consistencyPolicy is how long, and how often, to poll GitBook for the
+// result of a write.
+type consistencyPolicy struct {
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	timeout        time.Duration
+	// after waits for a backoff between attempts, like `time.After`.
+	after func(time.Duration) <-chan time.Time
+}
+
+var defaultConsistencyPolicy = consistencyPolicy{
+	initialBackoff: 250 * time.Millisecond,
+	maxBackoff:     5 * time.Second,
+	timeout:        2 * time.Minute,
+	after:          time.After,
+}
+
+// waitForConsistency polls GitBook after a write until the value returned by
+// `read` satisfies `matches`, with an exponential backoff between attempts.
+//
+// GitBook's read path can lag behind writes, so reading right after a write
+// may fail with a not-found error or return stale data. Retryable errors
+// returned by `read` are therefore retried until the timeout is reached, at
+// which point the last error (or a mismatch error) is returned.
+func waitForConsistency[T any](ctx context.Context, read func(ctx context.Context) (T, error), matches func(T) bool) (T, error) {
+	return pollForConsistency(ctx, defaultConsistencyPolicy, read, matches)
+}
+
+// pollForConsistency implements `waitForConsistency` with the given policy.
+func pollForConsistency[T any](ctx context.Context, policy consistencyPolicy, read func(ctx context.Context) (T, error), matches func(T) bool) (T, error) {
+	ctx, cancel := context.WithTimeout(ctx, policy.timeout)
+	defer cancel()
+
+	backoff := policy.initialBackoff
+	for attempt := 1; ; attempt++ {
+		value, err := read(ctx)
+		if err == nil && matches(value) {
+			return value, nil
+		}
+		if err != nil && !isRetryableError(err) {
+			return value, err
+		}
+		if err == nil {
+			err = fmt.Errorf("GitBook returned stale data after %d attempts", attempt)
+		}
+
+		tflog.Debug(ctx, "Waiting for GitBook to return the written data", map[string]interface{}{
+			"attempt": attempt,
+			"backoff": backoff.String(),
+			"error":   err.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return value, err
+		case <-policy.after(backoff):
+		}
+
+		backoff *= 2
+		if backoff > policy.maxBackoff {
+			backoff = policy.maxBackoff
+		}
+	}
+}
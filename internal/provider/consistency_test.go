@@ -0,0 +1,317 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	gitbook "github.com/GitbookIO/go-gitbook/api"
+)
+
+// newTestConsistencyPolicy returns a policy recording the backoffs into
+// `backoffs`, which doesn't wait in real time.
+func newTestConsistencyPolicy(backoffs *[]time.Duration) consistencyPolicy {
+	return consistencyPolicy{
+		initialBackoff: time.Second,
+		maxBackoff:     3 * time.Second,
+		timeout:        time.Hour,
+		after: func(backoff time.Duration) <-chan time.Time {
+			*backoffs = append(*backoffs, backoff)
+			elapsed := make(chan time.Time, 1)
+			elapsed <- time.Time{}
+			return elapsed
+		},
+	}
+}
+
+func TestPollForConsistencyErrors(t *testing.T) {
+	tests := map[string]struct {
+		err       error
+		wantRetry bool
+	}{
+		"not found": {
+			err:       newTestJSONAPIError(t, 404, `{"error":{"code":404,"message":"Not found"}}`),
+			wantRetry: true,
+		},
+		"rate limited": {
+			err:       newTestJSONAPIError(t, 429, `{"error":{"code":429,"message":"Slow down"}}`),
+			wantRetry: true,
+		},
+		"server": {
+			err:       newTestJSONAPIError(t, 503, `{"error":{"code":503,"message":"Unavailable"}}`),
+			wantRetry: true,
+		},
+		"not an API error": {
+			err:       errors.New("connection reset"),
+			wantRetry: true,
+		},
+		"validation": {
+			err: newTestJSONAPIError(t, 400, `{"error":{"code":400,"message":"Invalid"}}`),
+		},
+		"auth": {
+			err: newTestJSONAPIError(t, 401, `{"error":{"code":401,"message":"Unauthorized"}}`),
+		},
+		"conflict": {
+			err: newTestJSONAPIError(t, 409, `{"error":{"code":409,"message":"Conflict"}}`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var backoffs []time.Duration
+			attempts := 0
+			value, err := pollForConsistency(context.Background(), newTestConsistencyPolicy(&backoffs), func(ctx context.Context) (string, error) {
+				attempts++
+				if attempts == 1 {
+					return "", test.err
+				}
+				return "written", nil
+			}, func(value string) bool {
+				return value == "written"
+			})
+
+			if !test.wantRetry {
+				if err != test.err {
+					t.Errorf("expected error %q, got %v", test.err, err)
+				}
+				if attempts != 1 {
+					t.Errorf("expected 1 attempt, got %d", attempts)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if value != "written" {
+				t.Errorf("expected value %q, got %q", "written", value)
+			}
+			if attempts != 2 {
+				t.Errorf("expected 2 attempts, got %d", attempts)
+			}
+		})
+	}
+}
+
+func TestPollForConsistencyBackoff(t *testing.T) {
+	var backoffs []time.Duration
+	attempts := 0
+	_, err := pollForConsistency(context.Background(), newTestConsistencyPolicy(&backoffs), func(ctx context.Context) (int, error) {
+		attempts++
+		return attempts, nil
+	}, func(attempt int) bool {
+		return attempt == 6
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second, 3 * time.Second}
+	if len(backoffs) != len(want) {
+		t.Fatalf("expected backoffs %v, got %v", want, backoffs)
+	}
+	for i := range want {
+		if backoffs[i] != want[i] {
+			t.Errorf("expected backoffs %v, got %v", want, backoffs)
+			break
+		}
+	}
+}
+
+func TestPollForConsistencyTimeout(t *testing.T) {
+	policy := consistencyPolicy{
+		initialBackoff: time.Second,
+		maxBackoff:     time.Second,
+		timeout:        time.Nanosecond,
+		// Never elapses, so only the timeout ends the wait.
+		after: func(time.Duration) <-chan time.Time { return nil },
+	}
+
+	value, err := pollForConsistency(context.Background(), policy, func(ctx context.Context) (string, error) {
+		return "stale", nil
+	}, func(value string) bool {
+		return value == "written"
+	})
+	if err == nil || !strings.Contains(err.Error(), "stale data after 1 attempts") {
+		t.Errorf("expected a stale data error, got %v", err)
+	}
+	if value != "stale" {
+		t.Errorf("expected the last value %q, got %q", "stale", value)
+	}
+}
+
+func TestPollForConsistencyCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	policy := defaultConsistencyPolicy
+	policy.after = func(time.Duration) <-chan time.Time { return nil }
+	notFound := newTestJSONAPIError(t, 404, `{"error":{"code":404,"message":"Not found"}}`)
+
+	attempts := 0
+	_, err := pollForConsistency(ctx, policy, func(ctx context.Context) (string, error) {
+		attempts++
+		cancel()
+		return "", notFound
+	}, func(string) bool {
+		return true
+	})
+	if err != notFound {
+		t.Errorf("expected the last error %q, got %v", notFound, err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+// decodeTestEntity decodes an entity as returned by the GitBook API.
+func decodeTestEntity(t *testing.T, data string) *gitbook.Entity {
+	t.Helper()
+	var entity gitbook.Entity
+	if err := json.Unmarshal([]byte(data), &entity); err != nil {
+		t.Fatalf("decoding entity: %s", err)
+	}
+	return &entity
+}
+
+func TestUpsertEntityMatches(t *testing.T) {
+	text := "Example"
+	enabled := true
+	size := float32(0.1)
+	upserted := gitbook.UpsertEntity{
+		EntityId: "example",
+		Properties: map[string]gitbook.UpsertEntityPropertiesValue{
+			"name":    {String: &text},
+			"enabled": {Bool: &enabled},
+			"size":    {Float32: &size},
+			"owner":   {UpsertEntityPropertiesValueOneOf: gitbook.NewUpsertEntityPropertiesValueOneOf("team-a")},
+		},
+	}
+
+	tests := map[string]struct {
+		entity *gitbook.Entity
+		want   bool
+	}{
+		"nil": {
+			entity: nil,
+		},
+		"all properties": {
+			entity: decodeTestEntity(t, `{"entityId":"example","properties":{"name":"Example","enabled":true,"size":0.1,"owner":{"entityId":"team-a"}}}`),
+			want:   true,
+		},
+		"float returned in double precision": {
+			entity: decodeTestEntity(t, `{"entityId":"example","properties":{"name":"Example","enabled":true,"size":0.10000000149011612,"owner":{"entityId":"team-a"}}}`),
+			want:   true,
+		},
+		"extra property": {
+			entity: decodeTestEntity(t, `{"entityId":"example","properties":{"name":"Example","enabled":true,"size":0.1,"owner":{"entityId":"team-a"},"other":"x"}}`),
+			want:   true,
+		},
+		"missing property": {
+			entity: decodeTestEntity(t, `{"entityId":"example","properties":{"name":"Example","enabled":true,"size":0.1}}`),
+		},
+		"stale string": {
+			entity: decodeTestEntity(t, `{"entityId":"example","properties":{"name":"Old","enabled":true,"size":0.1,"owner":{"entityId":"team-a"}}}`),
+		},
+		"stale boolean": {
+			entity: decodeTestEntity(t, `{"entityId":"example","properties":{"name":"Example","enabled":false,"size":0.1,"owner":{"entityId":"team-a"}}}`),
+		},
+		"stale number": {
+			entity: decodeTestEntity(t, `{"entityId":"example","properties":{"name":"Example","enabled":true,"size":0.2,"owner":{"entityId":"team-a"}}}`),
+		},
+		"stale relation": {
+			entity: decodeTestEntity(t, `{"entityId":"example","properties":{"name":"Example","enabled":true,"size":0.1,"owner":{"entityId":"team-b"}}}`),
+		},
+		"value of another type": {
+			entity: decodeTestEntity(t, `{"entityId":"example","properties":{"name":"Example","enabled":"true","size":0.1,"owner":{"entityId":"team-a"}}}`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := upsertEntityMatches(upserted, test.entity); got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestEntityRawSchemaMatches(t *testing.T) {
+	description := "The team owning the service"
+	rawSchema := gitbook.EntityRawSchema{
+		Type:  "terraform:service",
+		Title: gitbook.EntityRawSchemaTitle{Singular: "Service", Plural: "Services"},
+		Properties: []gitbook.EntityPropertySchema{
+			{Name: "name", Title: "Name", Type: "text"},
+			{Name: "owner", Title: "Owner", Description: &description, Type: "relation", Entity: map[string]interface{}{"type": "terraform:team"}},
+		},
+	}
+
+	deprecated := true
+	otherDescription := "Another description"
+	name := gitbook.EntityPropertySchema{Name: "name", Title: "Name", Type: "text"}
+	owner := gitbook.EntityPropertySchema{Name: "owner", Title: "Owner", Description: &description, Type: "relation", Entity: map[string]interface{}{"type": "terraform:team"}}
+	withTitle := func(prop gitbook.EntityPropertySchema, title string) gitbook.EntityPropertySchema {
+		prop.Title = title
+		return prop
+	}
+
+	tests := map[string]struct {
+		title      gitbook.EntityRawSchemaTitle
+		properties []gitbook.EntityPropertySchema
+		want       bool
+	}{
+		"matching": {
+			properties: []gitbook.EntityPropertySchema{name, owner},
+			want:       true,
+		},
+		"stale title": {
+			title:      gitbook.EntityRawSchemaTitle{Singular: "Old", Plural: "Olds"},
+			properties: []gitbook.EntityPropertySchema{name, owner},
+		},
+		"stale order": {
+			properties: []gitbook.EntityPropertySchema{owner, name},
+		},
+		"missing property": {
+			properties: []gitbook.EntityPropertySchema{name},
+		},
+		"stale property title": {
+			properties: []gitbook.EntityPropertySchema{withTitle(name, "Old"), owner},
+		},
+		"stale property type": {
+			properties: []gitbook.EntityPropertySchema{{Name: "name", Title: "Name", Type: "number"}, owner},
+		},
+		"stale description": {
+			properties: []gitbook.EntityPropertySchema{name, {Name: "owner", Title: "Owner", Description: &otherDescription, Type: "relation", Entity: map[string]interface{}{"type": "terraform:team"}}},
+		},
+		"stale relation": {
+			properties: []gitbook.EntityPropertySchema{name, {Name: "owner", Title: "Owner", Description: &description, Type: "relation", Entity: map[string]interface{}{"type": "terraform:other"}}},
+		},
+		"removed property returned as deprecated": {
+			properties: []gitbook.EntityPropertySchema{name, {Name: "old", Title: "Old", Type: "text", Deprecated: &deprecated}, owner},
+			want:       true,
+		},
+		"removed property still returned": {
+			properties: []gitbook.EntityPropertySchema{name, owner, {Name: "old", Title: "Old", Type: "text"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			title := rawSchema.Title
+			if test.title != (gitbook.EntityRawSchemaTitle{}) {
+				title = test.title
+			}
+			entitySchema := &gitbook.EntitySchema{Type: rawSchema.Type, Title: title, Properties: test.properties}
+			if got := entityRawSchemaMatches(rawSchema, entitySchema); got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+
+	if entityRawSchemaMatches(rawSchema, nil) {
+		t.Errorf("expected a nil entity schema not to match")
+	}
+}
@@ -0,0 +1,239 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	gitbook "github.com/GitbookIO/go-gitbook/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func NewEntitiesDataSource() datasource.DataSource {
+	return &entitiesDataSource{}
+}
+
+// entitiesDataSource defines the data source implementation.
+type entitiesDataSource struct {
+	client *gitbook.OrganizationsApiService
+}
+
+type entitiesDataSourceModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+	Type           types.String `tfsdk:"type"`
+	Query          types.String `tfsdk:"query"`
+	EntityIDPrefix types.String `tfsdk:"entity_id_prefix"`
+	PropertyEquals types.Map    `tfsdk:"property_equals"`
+	Entities       types.List   `tfsdk:"entities"`
+}
+
+var entitiesEntityAttributeTypes = map[string]attr.Type{
+	"id":         types.StringType,
+	"type":       types.StringType,
+	"entity_id":  types.StringType,
+	"properties": types.MapType{ElemType: types.ObjectType{AttrTypes: entityPropertyAttributeTypes}},
+	"urls":       types.ObjectType{AttrTypes: entityURLsAttributeTypes},
+}
+
+func (d *entitiesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entities"
+}
+
+func (d *entitiesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the entities of an entity schema, optionally filtered.",
+
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the organization that owns the entities.",
+			},
+			"type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The type of the entity schema of the entities.",
+			},
+			"query": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A query filtering the entities with the GitBook API, e.g. `name == 'something' && age >= 10`.",
+			},
+			"entity_id_prefix": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list entities whose `entity_id` starts with this prefix.",
+			},
+			"property_equals": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "Only list entities whose properties equal these values, where each key is the property name. " +
+					"Numbers and booleans are compared in their string form, e.g. `42` or `true`, and relations by the related `entity_id`.",
+			},
+			"entities": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The entities matching the filters, sorted by `entity_id`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The unique GitBook ID of the entity.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The type of the entity schema.",
+						},
+						"entity_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the entity, unique for the related entity schema.",
+						},
+						"properties": entityPropertiesDataSourceAttribute(
+							"Map of all properties of the entity, where each key is the property name and the value is an object with either a `string`, `number`, `boolean` or `relation` property.",
+						),
+						"urls": schema.SingleNestedAttribute{
+							Computed: true,
+							Attributes: map[string]schema.Attribute{
+								"location": schema.StringAttribute{
+									Computed: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *entitiesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*gitBookClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *gitBookClient, got: %T. Please report this issue to GitBook.", req.ProviderData),
+		)
+
+		return
+	}
+
+	client.addTokenError(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.client = client.OrganizationsApi
+}
+
+func (d *entitiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model entitiesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := model.OrganizationID.ValueString()
+	entityType := model.Type.ValueString()
+
+	propertyEquals := make(map[string]string, len(model.PropertyEquals.Elements()))
+	resp.Diagnostics.Append(model.PropertyEquals.ElementsAs(ctx, &propertyEquals, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// List the entities via the GitBook API, paging through the results.
+	entities, err := queryListSchemaEntities(ctx, d.client, organizationID, entityType, model.Query.ValueString())
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error listing GitBook entities",
+			fmt.Sprintf("Could not list entities of GitBook entity schema (organization: %q, type: %q)", organizationID, entityType),
+			err,
+		)
+		return
+	}
+
+	sort.Slice(entities, func(i, j int) bool { return entities[i].EntityId < entities[j].EntityId })
+
+	values := make([]attr.Value, 0, len(entities))
+	for _, entity := range entities {
+		if !strings.HasPrefix(entity.EntityId, model.EntityIDPrefix.ValueString()) || !entityPropertiesEqual(entity, propertyEquals) {
+			continue
+		}
+
+		value := entitiesEntityValue(model, entity, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		values = append(values, value)
+	}
+
+	entitiesValue, diags := types.ListValue(types.ObjectType{AttrTypes: entitiesEntityAttributeTypes}, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	model.Entities = entitiesValue
+
+	// Save data into Terraform state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// entitiesEntityValue converts an entity from GitBook into an element of
+// `entities`, with all of its properties.
+func entitiesEntityValue(model entitiesDataSourceModel, entity gitbook.Entity, diags *diag.Diagnostics) attr.Value {
+	entityModel := entityModel{
+		OrganizationID: model.OrganizationID,
+		Properties:     types.MapNull(types.ObjectType{AttrTypes: entityPropertyAttributeTypes}),
+	}
+	entityModel.parseEntity(&entity, diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	value, d := types.ObjectValue(entitiesEntityAttributeTypes, map[string]attr.Value{
+		"id":         entityModel.ID,
+		"type":       entityModel.Type,
+		"entity_id":  entityModel.EntityID,
+		"properties": entityModel.Properties,
+		"urls":       entityModel.URLs,
+	})
+	diags.Append(d...)
+	return value
+}
+
+// entityPropertiesEqual reports whether the properties of an entity equal the
+// expected values, compared in their string form.
+func entityPropertiesEqual(entity gitbook.Entity, expected map[string]string) bool {
+	for name, expectedValue := range expected {
+		value, ok := entity.Properties[name]
+		if !ok {
+			return false
+		}
+
+		var actual string
+		switch v := value.GetActualInstance().(type) {
+		case *string:
+			actual = *v
+		case *bool:
+			actual = strconv.FormatBool(*v)
+		case *float32:
+			actual = strconv.FormatFloat(float64(*v), 'f', -1, 32)
+		case *gitbook.UpsertEntityPropertiesValueOneOf:
+			actual = v.EntityId
+		default:
+			return false
+		}
+		if actual != expectedValue {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,83 @@
+package provider
+
+import "testing"
+
+func TestEntityPropertiesEqual(t *testing.T) {
+	entity := decodeTestEntity(t, `{
+		"entityId": "example",
+		"properties": {
+			"name": "Example",
+			"enabled": true,
+			"disabled": false,
+			"ratio": 0.1,
+			"count": 3,
+			"owner": {"entityId": "team-a"}
+		}
+	}`)
+
+	tests := map[string]struct {
+		expected map[string]string
+		want     bool
+	}{
+		"no filter": {
+			expected: map[string]string{},
+			want:     true,
+		},
+		"string": {
+			expected: map[string]string{"name": "Example"},
+			want:     true,
+		},
+		"other string": {
+			expected: map[string]string{"name": "example"},
+		},
+		"true": {
+			expected: map[string]string{"enabled": "true"},
+			want:     true,
+		},
+		"false": {
+			expected: map[string]string{"disabled": "false"},
+			want:     true,
+		},
+		"other boolean": {
+			expected: map[string]string{"enabled": "false"},
+		},
+		// The number is formatted with float32 precision, so that it's not
+		// rendered as 0.10000000149011612.
+		"decimal number": {
+			expected: map[string]string{"ratio": "0.1"},
+			want:     true,
+		},
+		"integer number": {
+			expected: map[string]string{"count": "3"},
+			want:     true,
+		},
+		"other number": {
+			expected: map[string]string{"count": "4"},
+		},
+		"relation": {
+			expected: map[string]string{"owner": "team-a"},
+			want:     true,
+		},
+		"other relation": {
+			expected: map[string]string{"owner": "team-b"},
+		},
+		"missing property": {
+			expected: map[string]string{"missing": ""},
+		},
+		"all of several properties": {
+			expected: map[string]string{"name": "Example", "ratio": "0.1", "owner": "team-a"},
+			want:     true,
+		},
+		"one of several properties": {
+			expected: map[string]string{"name": "Example", "ratio": "0.2"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := entityPropertiesEqual(*entity, test.expected); got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	gitbook "github.com/GitbookIO/go-gitbook/api"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultEntityBatchSize  = 50
+	defaultEntityBatchDelay = 100 * time.Millisecond
+)
+
+// entityBatcher coalesces entity upserts and deletes for the same organization
+// and entity type into a single `UpsertSchemaEntities` API call.
+//
+// Terraform applies resources concurrently, so a configuration with many
+// `gitbook_entity` resources of the same type results in many writes arriving
+// within a short window. Each write is queued in a pending batch, which is
+// sent once it is full or once the flush delay has elapsed since the first
+// write was queued. The result of the API call is then returned to every
+// caller that contributed to the batch. When the API rejects a batch, its
+// writes are retried one by one, so that each caller gets its own error.
+type entityBatcher struct {
+	// send writes a batch to the GitBook API.
+	send    func(ctx context.Context, key entityBatchKey, req gitbook.UpsertSchemaEntitiesRequest) error
+	maxSize int
+	delay   time.Duration
+
+	mu      sync.Mutex
+	pending map[entityBatchKey]*entityBatch
+}
+
+type entityBatchKey struct {
+	organizationID string
+	entityType     string
+}
+
+type entityBatch struct {
+	key    entityBatchKey
+	writes []entityWrite
+	// errs are the errors of the writes, by entity ID. The entity IDs of a
+	// batch are unique.
+	errs map[string]error
+
+	timer *time.Timer
+	once  sync.Once
+	done  chan struct{}
+}
+
+// entityWrite is an entity upsert, or an entity delete when entity is nil.
+type entityWrite struct {
+	entityID string
+	entity   *gitbook.UpsertEntity
+}
+
+func newEntityBatcher(client *gitbook.OrganizationsApiService, maxSize int, delay time.Duration) *entityBatcher {
+	if maxSize < 1 {
+		maxSize = 1
+	}
+	return &entityBatcher{
+		send: func(ctx context.Context, key entityBatchKey, req gitbook.UpsertSchemaEntitiesRequest) error {
+			_, err := client.UpsertSchemaEntities(ctx, key.organizationID, key.entityType).UpsertSchemaEntitiesRequest(req).Execute()
+			return err
+		},
+		maxSize: maxSize,
+		delay:   delay,
+		pending: make(map[entityBatchKey]*entityBatch),
+	}
+}
+
+func (b *entityBatch) has(entityID string) bool {
+	for _, write := range b.writes {
+		if write.entityID == entityID {
+			return true
+		}
+	}
+	return false
+}
+
+// Upsert queues an entity to be created or updated, and blocks until the batch
+// it is part of has been sent.
+func (b *entityBatcher) Upsert(ctx context.Context, organizationID, entityType string, entity gitbook.UpsertEntity) error {
+	return b.enqueue(ctx, entityBatchKey{organizationID, entityType}, entityWrite{entityID: entity.EntityId, entity: &entity})
+}
+
+// Delete queues an entity to be deleted, and blocks until the batch it is part
+// of has been sent.
+func (b *entityBatcher) Delete(ctx context.Context, organizationID, entityType string, entityID string) error {
+	return b.enqueue(ctx, entityBatchKey{organizationID, entityType}, entityWrite{entityID: entityID})
+}
+
+func (b *entityBatcher) enqueue(ctx context.Context, key entityBatchKey, write entityWrite) error {
+	b.mu.Lock()
+	batch := b.pending[key]
+
+	// A single request can't hold more than one write for the same entity, so
+	// flush the pending batch first to preserve the order of writes.
+	for batch != nil && batch.has(write.entityID) {
+		b.mu.Unlock()
+		b.flush(context.WithoutCancel(ctx), batch)
+		b.mu.Lock()
+		batch = b.pending[key]
+	}
+
+	if batch == nil {
+		batch = &entityBatch{
+			key:  key,
+			errs: make(map[string]error),
+			done: make(chan struct{}),
+		}
+		b.pending[key] = batch
+		flushCtx := context.WithoutCancel(ctx)
+		batch.timer = time.AfterFunc(b.delay, func() {
+			b.flush(flushCtx, batch)
+		})
+	}
+	batch.writes = append(batch.writes, write)
+	full := len(batch.writes) >= b.maxSize
+	if full {
+		delete(b.pending, key)
+	}
+	b.mu.Unlock()
+
+	if full {
+		b.flush(context.WithoutCancel(ctx), batch)
+	}
+
+	select {
+	case <-batch.done:
+		return batch.errs[write.entityID]
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// flush sends a batch to the GitBook API. It is safe to call more than once;
+// only the first call sends the batch, subsequent calls wait for it to be sent.
+func (b *entityBatcher) flush(ctx context.Context, batch *entityBatch) {
+	batch.once.Do(func() {
+		b.mu.Lock()
+		batch.timer.Stop()
+		if b.pending[batch.key] == batch {
+			delete(b.pending, batch.key)
+		}
+		b.mu.Unlock()
+
+		tflog.Debug(ctx, fmt.Sprintf("Sending batch of %d entity writes", len(batch.writes)), map[string]interface{}{
+			"organization_id": batch.key.organizationID,
+			"type":            batch.key.entityType,
+		})
+
+		err := b.send(ctx, batch.key, entityBatchRequest(batch.writes))
+		if len(batch.writes) > 1 && isRejectedBatchError(err) {
+			// The error may be caused by any of the writes, and may even be
+			// scoped to a property of one entity, so retry the writes one by
+			// one to return each caller its own error.
+			tflog.Debug(ctx, "Batch of entity writes rejected, retrying writes one by one", map[string]interface{}{
+				"organization_id": batch.key.organizationID,
+				"type":            batch.key.entityType,
+				"error":           err.Error(),
+			})
+			for _, write := range batch.writes {
+				batch.errs[write.entityID] = b.send(ctx, batch.key, entityBatchRequest([]entityWrite{write}))
+			}
+		} else {
+			for _, write := range batch.writes {
+				batch.errs[write.entityID] = err
+			}
+		}
+		close(batch.done)
+	})
+	<-batch.done
+}
+
+// entityBatchRequest builds the request writing a batch of entities.
+func entityBatchRequest(writes []entityWrite) gitbook.UpsertSchemaEntitiesRequest {
+	req := gitbook.UpsertSchemaEntitiesRequest{
+		Entities: []gitbook.UpsertEntity{},
+	}
+	var deletes []string
+	for _, write := range writes {
+		if write.entity != nil {
+			req.Entities = append(req.Entities, *write.entity)
+		} else {
+			deletes = append(deletes, write.entityID)
+		}
+	}
+	if len(deletes) > 0 {
+		req.Delete = &gitbook.UpsertSchemaEntitiesRequestDelete{
+			ArrayOfString: &deletes,
+		}
+	}
+	return req
+}
+
+// isRejectedBatchError reports whether the GitBook API rejected a batch
+// because of its content, rather than e.g. authentication or rate limiting,
+// which would fail each of its writes as well.
+func isRejectedBatchError(err error) bool {
+	if err == nil {
+		return false
+	}
+	apiErr := parseAPIError(err)
+	switch apiErr.Kind {
+	case apiErrorKindAuth, apiErrorKindRateLimited:
+		return false
+	}
+	return apiErr.Status >= 400 && apiErr.Status < 500
+}
@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	gitbook "github.com/GitbookIO/go-gitbook/api"
+)
+
+// stubEntityBatchSender records the requests sent by an entityBatcher.
+type stubEntityBatchSender struct {
+	mu       sync.Mutex
+	requests []gitbook.UpsertSchemaEntitiesRequest
+	// fail returns the error of a request, if any.
+	fail func(req gitbook.UpsertSchemaEntitiesRequest) error
+}
+
+func (s *stubEntityBatchSender) send(ctx context.Context, key entityBatchKey, req gitbook.UpsertSchemaEntitiesRequest) error {
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	s.mu.Unlock()
+	if s.fail != nil {
+		return s.fail(req)
+	}
+	return nil
+}
+
+func newTestEntityBatcher(sender *stubEntityBatchSender, maxSize int, delay time.Duration) *entityBatcher {
+	batcher := newEntityBatcher(nil, maxSize, delay)
+	batcher.send = sender.send
+	return batcher
+}
+
+// upsertConcurrently upserts entities with the given IDs concurrently, and
+// returns their errors by entity ID.
+func upsertConcurrently(batcher *entityBatcher, entityIDs ...string) map[string]error {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	errs := make(map[string]error)
+	for _, entityID := range entityIDs {
+		wg.Add(1)
+		go func(entityID string) {
+			defer wg.Done()
+			err := batcher.Upsert(context.Background(), "org", "terraform:test", gitbook.UpsertEntity{EntityId: entityID})
+			mu.Lock()
+			errs[entityID] = err
+			mu.Unlock()
+		}(entityID)
+	}
+	wg.Wait()
+	return errs
+}
+
+func TestEntityBatcherFlushOnSize(t *testing.T) {
+	sender := &stubEntityBatchSender{}
+	// The delay is long enough to only flush when the batch is full.
+	batcher := newTestEntityBatcher(sender, 3, time.Hour)
+
+	errs := upsertConcurrently(batcher, "a", "b", "c")
+	for entityID, err := range errs {
+		if err != nil {
+			t.Errorf("unexpected error for entity %q: %s", entityID, err)
+		}
+	}
+
+	if len(sender.requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(sender.requests))
+	}
+	if got := len(sender.requests[0].Entities); got != 3 {
+		t.Errorf("expected 3 entities in the request, got %d", got)
+	}
+}
+
+func TestEntityBatcherFlushOnTimer(t *testing.T) {
+	sender := &stubEntityBatchSender{}
+	batcher := newTestEntityBatcher(sender, 50, 10*time.Millisecond)
+
+	err := batcher.Delete(context.Background(), "org", "terraform:test", "a")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(sender.requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(sender.requests))
+	}
+	req := sender.requests[0]
+	if len(req.Entities) != 0 || req.Delete == nil || len(*req.Delete.ArrayOfString) != 1 || (*req.Delete.ArrayOfString)[0] != "a" {
+		t.Errorf("expected a request deleting entity \"a\", got %+v", req)
+	}
+}
+
+func TestEntityBatcherFlushBeforeDuplicateEntityID(t *testing.T) {
+	sender := &stubEntityBatchSender{}
+	batcher := newTestEntityBatcher(sender, 50, 50*time.Millisecond)
+
+	upserted := make(chan error)
+	go func() {
+		upserted <- batcher.Upsert(context.Background(), "org", "terraform:test", gitbook.UpsertEntity{EntityId: "a"})
+	}()
+	// Wait for the upsert to be queued.
+	for {
+		batcher.mu.Lock()
+		queued := batcher.pending[entityBatchKey{"org", "terraform:test"}] != nil
+		batcher.mu.Unlock()
+		if queued {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	err := batcher.Delete(context.Background(), "org", "terraform:test", "a")
+	if err != nil {
+		t.Fatalf("unexpected delete error: %s", err)
+	}
+	if err := <-upserted; err != nil {
+		t.Fatalf("unexpected upsert error: %s", err)
+	}
+
+	if len(sender.requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(sender.requests))
+	}
+	if len(sender.requests[0].Entities) != 1 || sender.requests[0].Delete != nil {
+		t.Errorf("expected the first request to upsert entity \"a\", got %+v", sender.requests[0])
+	}
+	if len(sender.requests[1].Entities) != 0 || sender.requests[1].Delete == nil {
+		t.Errorf("expected the second request to delete entity \"a\", got %+v", sender.requests[1])
+	}
+}
+
+func TestEntityBatcherErrorFanOut(t *testing.T) {
+	tests := map[string]struct {
+		err error
+		// wantRetries is whether the writes are retried one by one.
+		wantRetries bool
+	}{
+		"validation error": {
+			err:         newTestJSONAPIError(t, 400, `{"error":{"code":400,"message":"Invalid property \"name\""}}`),
+			wantRetries: true,
+		},
+		"server error": {
+			err: newTestJSONAPIError(t, 500, `{"error":{"code":500,"message":"Internal error"}}`),
+		},
+		"auth error": {
+			err: newTestJSONAPIError(t, 401, `{"error":{"code":401,"message":"Unauthorized"}}`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Only requests including the entity "bad" fail.
+			sender := &stubEntityBatchSender{
+				fail: func(req gitbook.UpsertSchemaEntitiesRequest) error {
+					for _, entity := range req.Entities {
+						if entity.EntityId == "bad" {
+							return test.err
+						}
+					}
+					return nil
+				},
+			}
+			batcher := newTestEntityBatcher(sender, 3, time.Hour)
+
+			errs := upsertConcurrently(batcher, "a", "bad", "b")
+
+			if errs["bad"] != test.err {
+				t.Errorf("expected entity \"bad\" to fail with %q, got %v", test.err, errs["bad"])
+			}
+			for _, entityID := range []string{"a", "b"} {
+				if test.wantRetries && errs[entityID] != nil {
+					t.Errorf("expected entity %q to succeed, got %q", entityID, errs[entityID])
+				}
+				if !test.wantRetries && errs[entityID] != test.err {
+					t.Errorf("expected entity %q to fail with %q, got %v", entityID, test.err, errs[entityID])
+				}
+			}
+
+			wantRequests := 1
+			if test.wantRetries {
+				wantRequests = 4
+			}
+			if len(sender.requests) != wantRequests {
+				t.Errorf("expected %d requests, got %d", wantRequests, len(sender.requests))
+			}
+		})
+	}
+}
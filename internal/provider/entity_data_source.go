@@ -5,8 +5,13 @@ import (
 	"fmt"
 
 	gitbook "github.com/GitbookIO/go-gitbook/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func NewEntityDataSource() datasource.DataSource {
@@ -18,6 +23,33 @@ type entityDataSource struct {
 	client *gitbook.OrganizationsApiService
 }
 
+// entityDataSourceModel is the subset of `entityModel` exposed by the data
+// source, which has no resource-only attributes.
+type entityDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+	Type           types.String `tfsdk:"type"`
+	EntityID       types.String `tfsdk:"entity_id"`
+	Properties     types.Map    `tfsdk:"properties"`
+	URLs           types.Object `tfsdk:"urls"`
+}
+
+// parseEntity merges an Entity from GitBook into the data source model, with
+// all of its properties.
+func (m *entityDataSourceModel) parseEntity(entity *gitbook.Entity, diags *diag.Diagnostics) {
+	model := entityModel{
+		OrganizationID: m.OrganizationID,
+		Properties:     types.MapNull(types.ObjectType{AttrTypes: entityPropertyAttributeTypes}),
+	}
+	model.parseEntity(entity, diags)
+
+	m.ID = model.ID
+	m.Type = model.Type
+	m.EntityID = model.EntityID
+	m.Properties = model.Properties
+	m.URLs = model.URLs
+}
+
 func (d *entityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_entity"
 }
@@ -30,40 +62,34 @@ func (d *entityDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Optional: true,
-			},
-			"type": schema.StringAttribute{
-				Required: true,
+				Computed: true,
+				MarkdownDescription: "The unique GitBook ID of the entity. Exactly one of `id` or `entity_id` must be set. " +
+					"As GitBook can't look up entities by this ID, the entities of the organization are searched for it, " +
+					"or only those of `type` when it's set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("entity_id")),
+				},
 			},
 			"organization_id": schema.StringAttribute{
-				Required: true,
+				Required:            true,
+				MarkdownDescription: "The ID of the organization that owns the entity.",
 			},
-			"entity_id": schema.StringAttribute{
-				Required: true,
+			"type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The type of the entity schema. Required when looking up the entity by `entity_id`.",
 			},
-			"properties": schema.MapNestedAttribute{
-				Optional: true,
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"string": schema.StringAttribute{
-							Optional: true,
-						},
-						"number": schema.NumberAttribute{
-							Optional: true,
-						},
-						"boolean": schema.BoolAttribute{
-							Optional: true,
-						},
-						"relation": schema.SingleNestedAttribute{
-							Optional: true,
-							Attributes: map[string]schema.Attribute{
-								"entity_id": schema.StringAttribute{
-									Required: true,
-								},
-							},
-						},
-					},
+			"entity_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The ID of the entity, unique for the related entity schema.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("type")),
 				},
 			},
+			"properties": entityPropertiesDataSourceAttribute(
+				"Map of all properties of the entity, where each key is the property name and the value is an object with either a `string`, `number`, `boolean` or `relation` property.",
+			),
 			"urls": schema.SingleNestedAttribute{
 				Computed: true,
 				Attributes: map[string]schema.Attribute{
@@ -76,28 +102,63 @@ func (d *entityDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 	}
 }
 
+// entityPropertiesDataSourceAttribute is a computed map of entity properties
+// for data sources reading entities.
+func entityPropertiesDataSourceAttribute(description string) schema.MapNestedAttribute {
+	return schema.MapNestedAttribute{
+		Computed:            true,
+		MarkdownDescription: description,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"string": schema.StringAttribute{
+					Computed: true,
+				},
+				"number": schema.NumberAttribute{
+					Computed: true,
+				},
+				"boolean": schema.BoolAttribute{
+					Computed: true,
+				},
+				"relation": schema.SingleNestedAttribute{
+					Computed: true,
+					Attributes: map[string]schema.Attribute{
+						"entity_id": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func (d *entityDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*gitbook.APIClient)
+	client, ok := req.ProviderData.(*gitBookClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *gitbook.APIClient, got: %T. Please report this issue to GitBook.", req.ProviderData),
+			fmt.Sprintf("Expected *gitBookClient, got: %T. Please report this issue to GitBook.", req.ProviderData),
 		)
 
 		return
 	}
 
+	client.addTokenError(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	d.client = client.OrganizationsApi
 }
 
 func (d *entityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	// Get current state.
-	state := &entityModel{}
+	state := &entityDataSourceModel{}
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -105,14 +166,29 @@ func (d *entityDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	organizationID := state.OrganizationID.ValueString()
 	entityType := state.Type.ValueString()
-	entityID := state.EntityID.ValueString()
 
-	entity, _, err := d.client.GetEntity(ctx, organizationID, entityType, entityID).Execute()
+	var entity *gitbook.Entity
+	var err error
+	if !state.ID.IsNull() {
+		id := state.ID.ValueString()
+		entity, err = findEntityByID(ctx, d.client, organizationID, entityType, id)
+		if err == nil && entity == nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("id"),
+				"GitBook entity not found",
+				fmt.Sprintf("No GitBook entity with ID %q was found in organization %q.", id, organizationID),
+			)
+			return
+		}
+	} else {
+		entity, _, err = d.client.GetEntity(ctx, organizationID, entityType, state.EntityID.ValueString()).Execute()
+	}
 	if err != nil {
-		errMessage := parseErrorMessage(err)
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Error reading GitBook entity",
-			fmt.Sprintf("Could not read GitBook entity: %v", errMessage),
+			"Could not read GitBook entity",
+			err,
 		)
 		return
 	}
@@ -0,0 +1,274 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	gitbook "github.com/GitbookIO/go-gitbook/api"
+)
+
+// entityGraphNode is an entity reached while traversing relations, at the
+// given depth from the root entity.
+type entityGraphNode struct {
+	Entity gitbook.Entity
+	Depth  int
+}
+
+// entityGraphEdge is a relation property of an entity, from the entity holding
+// the property to the related entity.
+type entityGraphEdge struct {
+	FromType     string
+	FromEntityID string
+	Property     string
+	ToType       string
+	ToEntityID   string
+}
+
+// entityGraphRelation is a relation property of an entity schema.
+type entityGraphRelation struct {
+	Type     string
+	Property string
+	ToType   string
+}
+
+// entityGraph traverses the relations between the entities of an organization.
+// The entities of an entity schema are listed at most once, to find incoming
+// relations.
+type entityGraph struct {
+	// fetchEntity gets an entity from the GitBook API.
+	fetchEntity func(ctx context.Context, entityType, entityID string) (*gitbook.Entity, error)
+	// fetchEntities lists the entities of an entity schema from the GitBook API.
+	fetchEntities func(ctx context.Context, entityType string) ([]gitbook.Entity, error)
+
+	// relations are the relation properties of every entity schema.
+	relations []entityGraphRelation
+	// listed are the entities of the entity schemas listed so far, by type and
+	// entity ID.
+	listed map[string]map[string]gitbook.Entity
+
+	// Dangling are the relations to entities that don't exist.
+	Dangling []entityGraphEdge
+}
+
+func newEntityGraph(ctx context.Context, client *gitbook.OrganizationsApiService, organizationID string) (*entityGraph, error) {
+	entitySchemas, err := listEntitySchemas(ctx, client, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entityGraph{
+		fetchEntity: func(ctx context.Context, entityType, entityID string) (*gitbook.Entity, error) {
+			entity, _, err := client.GetEntity(ctx, organizationID, entityType, entityID).Execute()
+			return entity, err
+		},
+		fetchEntities: func(ctx context.Context, entityType string) ([]gitbook.Entity, error) {
+			return listSchemaEntities(ctx, client, organizationID, entityType)
+		},
+		relations: entityGraphRelations(entitySchemas),
+		listed:    make(map[string]map[string]gitbook.Entity),
+	}, nil
+}
+
+// entityGraphRelations returns the relation properties of entity schemas.
+func entityGraphRelations(entitySchemas []gitbook.EntitySchema) []entityGraphRelation {
+	var relations []entityGraphRelation
+	for _, entitySchema := range entitySchemas {
+		for _, property := range entitySchema.Properties {
+			toType, _ := property.Entity["type"].(string)
+			if property.Type != "relation" || toType == "" {
+				continue
+			}
+			relations = append(relations, entityGraphRelation{
+				Type:     entitySchema.Type,
+				Property: property.Name,
+				ToType:   toType,
+			})
+		}
+	}
+	return relations
+}
+
+// Traverse follows the outgoing and incoming relations of the root entity, up
+// to `maxDepth` relations away. Nodes are sorted by depth, type and entity ID,
+// and edges by their source, property and target.
+func (g *entityGraph) Traverse(ctx context.Context, entityType, entityID string, maxDepth int, outgoing, incoming bool) ([]entityGraphNode, []entityGraphEdge, error) {
+	root, err := g.getEntity(ctx, entityType, entityID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nodes := map[string]*entityGraphNode{
+		entityGraphKey(entityType, entityID): {Entity: *root, Depth: 0},
+	}
+	edges := make(map[entityGraphEdge]bool)
+	queue := []*entityGraphNode{nodes[entityGraphKey(entityType, entityID)]}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node.Depth >= maxDepth {
+			continue
+		}
+
+		var found []entityGraphEdge
+		if outgoing {
+			found = append(found, g.outgoing(node.Entity)...)
+		}
+		if incoming {
+			in, err := g.incoming(ctx, node.Entity)
+			if err != nil {
+				return nil, nil, err
+			}
+			found = append(found, in...)
+		}
+
+		for _, edge := range found {
+			if edges[edge] {
+				continue
+			}
+
+			// Add the node at the other end of the relation.
+			otherType, otherID := edge.ToType, edge.ToEntityID
+			if otherType == node.Entity.Type && otherID == node.Entity.EntityId {
+				otherType, otherID = edge.FromType, edge.FromEntityID
+			}
+			key := entityGraphKey(otherType, otherID)
+			if _, ok := nodes[key]; !ok {
+				other, err := g.getEntity(ctx, otherType, otherID)
+				if isNotFoundError(err) {
+					g.Dangling = append(g.Dangling, edge)
+					continue
+				}
+				if err != nil {
+					return nil, nil, err
+				}
+				nodes[key] = &entityGraphNode{Entity: *other, Depth: node.Depth + 1}
+				queue = append(queue, nodes[key])
+			}
+
+			edges[edge] = true
+		}
+	}
+
+	sortedNodes := make([]entityGraphNode, 0, len(nodes))
+	for _, node := range nodes {
+		sortedNodes = append(sortedNodes, *node)
+	}
+	sort.Slice(sortedNodes, func(i, j int) bool {
+		a, b := sortedNodes[i], sortedNodes[j]
+		if a.Depth != b.Depth {
+			return a.Depth < b.Depth
+		}
+		return entityGraphKey(a.Entity.Type, a.Entity.EntityId) < entityGraphKey(b.Entity.Type, b.Entity.EntityId)
+	})
+
+	sortedEdges := make([]entityGraphEdge, 0, len(edges))
+	for edge := range edges {
+		sortedEdges = append(sortedEdges, edge)
+	}
+	sortEntityGraphEdges(sortedEdges)
+	sortEntityGraphEdges(g.Dangling)
+
+	return sortedNodes, sortedEdges, nil
+}
+
+// outgoing returns the relations from an entity to other entities.
+func (g *entityGraph) outgoing(entity gitbook.Entity) []entityGraphEdge {
+	var edges []entityGraphEdge
+	for _, relation := range g.relations {
+		if relation.Type != entity.Type {
+			continue
+		}
+		value, ok := entity.Properties[relation.Property]
+		if !ok || value.UpsertEntityPropertiesValueOneOf == nil {
+			continue
+		}
+		edges = append(edges, entityGraphEdge{
+			FromType:     entity.Type,
+			FromEntityID: entity.EntityId,
+			Property:     relation.Property,
+			ToType:       relation.ToType,
+			ToEntityID:   value.UpsertEntityPropertiesValueOneOf.EntityId,
+		})
+	}
+	return edges
+}
+
+// incoming returns the relations from other entities to an entity.
+func (g *entityGraph) incoming(ctx context.Context, entity gitbook.Entity) ([]entityGraphEdge, error) {
+	var edges []entityGraphEdge
+	for _, relation := range g.relations {
+		if relation.ToType != entity.Type {
+			continue
+		}
+		entities, err := g.listEntities(ctx, relation.Type)
+		if err != nil {
+			return nil, err
+		}
+		for _, from := range entities {
+			value, ok := from.Properties[relation.Property]
+			if !ok || value.UpsertEntityPropertiesValueOneOf == nil || value.UpsertEntityPropertiesValueOneOf.EntityId != entity.EntityId {
+				continue
+			}
+			edges = append(edges, entityGraphEdge{
+				FromType:     from.Type,
+				FromEntityID: from.EntityId,
+				Property:     relation.Property,
+				ToType:       entity.Type,
+				ToEntityID:   entity.EntityId,
+			})
+		}
+	}
+	return edges, nil
+}
+
+// getEntity gets an entity, from the entities listed so far if possible.
+func (g *entityGraph) getEntity(ctx context.Context, entityType, entityID string) (*gitbook.Entity, error) {
+	if entities, ok := g.listed[entityType]; ok {
+		if entity, ok := entities[entityID]; ok {
+			return &entity, nil
+		}
+	}
+	return g.fetchEntity(ctx, entityType, entityID)
+}
+
+// listEntities lists the entities of an entity schema, at most once.
+func (g *entityGraph) listEntities(ctx context.Context, entityType string) (map[string]gitbook.Entity, error) {
+	if entities, ok := g.listed[entityType]; ok {
+		return entities, nil
+	}
+
+	list, err := g.fetchEntities(ctx, entityType)
+	if err != nil {
+		return nil, err
+	}
+	entities := make(map[string]gitbook.Entity, len(list))
+	for _, entity := range list {
+		entities[entity.EntityId] = entity
+	}
+	g.listed[entityType] = entities
+	return entities, nil
+}
+
+func entityGraphKey(entityType, entityID string) string {
+	return entityType + "/" + entityID
+}
+
+func sortEntityGraphEdges(edges []entityGraphEdge) {
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		if a.FromType != b.FromType {
+			return a.FromType < b.FromType
+		}
+		if a.FromEntityID != b.FromEntityID {
+			return a.FromEntityID < b.FromEntityID
+		}
+		if a.Property != b.Property {
+			return a.Property < b.Property
+		}
+		if a.ToType != b.ToType {
+			return a.ToType < b.ToType
+		}
+		return a.ToEntityID < b.ToEntityID
+	})
+}
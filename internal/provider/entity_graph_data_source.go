@@ -0,0 +1,289 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	gitbook "github.com/GitbookIO/go-gitbook/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const defaultEntityGraphDepth = 1
+
+func NewEntityGraphDataSource() datasource.DataSource {
+	return &entityGraphDataSource{}
+}
+
+// entityGraphDataSource defines the data source implementation.
+type entityGraphDataSource struct {
+	client *gitbook.OrganizationsApiService
+}
+
+type entityGraphDataSourceModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+	Type           types.String `tfsdk:"type"`
+	EntityID       types.String `tfsdk:"entity_id"`
+	Depth          types.Int64  `tfsdk:"depth"`
+	Direction      types.String `tfsdk:"direction"`
+	Nodes          types.List   `tfsdk:"nodes"`
+	Edges          types.List   `tfsdk:"edges"`
+}
+
+var entityGraphNodeAttributeTypes = map[string]attr.Type{
+	"id":         types.StringType,
+	"type":       types.StringType,
+	"entity_id":  types.StringType,
+	"depth":      types.Int64Type,
+	"properties": types.MapType{ElemType: types.ObjectType{AttrTypes: entityPropertyAttributeTypes}},
+}
+
+var entityGraphEdgeAttributeTypes = map[string]attr.Type{
+	"from_type":      types.StringType,
+	"from_entity_id": types.StringType,
+	"property":       types.StringType,
+	"to_type":        types.StringType,
+	"to_entity_id":   types.StringType,
+}
+
+func (d *entityGraphDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entity_graph"
+}
+
+func (d *entityGraphDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Traverses the relations between entities, starting from a root entity. " +
+			"Relations are the `relation` properties of entity schemas.",
+
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the organization that owns the entities.",
+			},
+			"type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The type of the entity schema of the root entity.",
+			},
+			"entity_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the root entity, unique for the related entity schema.",
+			},
+			"depth": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("The maximum number of relations between the root entity and the other entities. Defaults to `%d`.", defaultEntityGraphDepth),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"direction": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "The relations to follow: `outgoing` relations from an entity to other entities, " +
+					"`incoming` relations from other entities to an entity, or `both`. Defaults to `both`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("outgoing", "incoming", "both"),
+				},
+			},
+			"nodes": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The entities reached, including the root entity, sorted by depth, type and entity ID.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The unique GitBook ID of the entity.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The type of the entity schema.",
+						},
+						"entity_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the entity, unique for the related entity schema.",
+						},
+						"depth": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The number of relations between the root entity and the entity.",
+						},
+						"properties": entityPropertiesDataSourceAttribute(
+							"Map of all properties of the entity, where each key is the property name and the value is an object with either a `string`, `number`, `boolean` or `relation` property.",
+						),
+					},
+				},
+			},
+			"edges": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The relations between the entities reached, sorted by source, property and target.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"from_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The type of the entity holding the relation property.",
+						},
+						"from_entity_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the entity holding the relation property.",
+						},
+						"property": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the relation property.",
+						},
+						"to_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The type of the related entity.",
+						},
+						"to_entity_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the related entity.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *entityGraphDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*gitBookClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *gitBookClient, got: %T. Please report this issue to GitBook.", req.ProviderData),
+		)
+
+		return
+	}
+
+	client.addTokenError(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.client = client.OrganizationsApi
+}
+
+func (d *entityGraphDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model entityGraphDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := model.OrganizationID.ValueString()
+	entityType := model.Type.ValueString()
+	entityID := model.EntityID.ValueString()
+
+	depth := defaultEntityGraphDepth
+	if !model.Depth.IsNull() {
+		depth = int(model.Depth.ValueInt64())
+	}
+	direction := "both"
+	if !model.Direction.IsNull() {
+		direction = model.Direction.ValueString()
+	}
+
+	graph, err := newEntityGraph(ctx, d.client, organizationID)
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error listing GitBook entity schemas",
+			fmt.Sprintf("Could not list the GitBook entity schemas of organization %q to find relations", organizationID),
+			err,
+		)
+		return
+	}
+
+	nodes, edges, err := graph.Traverse(ctx, entityType, entityID, depth, direction != "incoming", direction != "outgoing")
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error traversing GitBook entity relations",
+			fmt.Sprintf("Could not traverse the relations of GitBook entity %q (type: %q)", entityID, entityType),
+			err,
+		)
+		return
+	}
+
+	addDanglingRelationWarnings(&resp.Diagnostics, graph.Dangling)
+
+	nodeValues := make([]attr.Value, 0, len(nodes))
+	for _, node := range nodes {
+		value := entityGraphNodeValue(model, node, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		nodeValues = append(nodeValues, value)
+	}
+	nodesValue, diags := types.ListValue(types.ObjectType{AttrTypes: entityGraphNodeAttributeTypes}, nodeValues)
+	resp.Diagnostics.Append(diags...)
+
+	edgeValues := make([]attr.Value, 0, len(edges))
+	for _, edge := range edges {
+		value, diags := types.ObjectValue(entityGraphEdgeAttributeTypes, map[string]attr.Value{
+			"from_type":      types.StringValue(edge.FromType),
+			"from_entity_id": types.StringValue(edge.FromEntityID),
+			"property":       types.StringValue(edge.Property),
+			"to_type":        types.StringValue(edge.ToType),
+			"to_entity_id":   types.StringValue(edge.ToEntityID),
+		})
+		resp.Diagnostics.Append(diags...)
+		edgeValues = append(edgeValues, value)
+	}
+	edgesValue, diags := types.ListValue(types.ObjectType{AttrTypes: entityGraphEdgeAttributeTypes}, edgeValues)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.Nodes = nodesValue
+	model.Edges = edgesValue
+
+	// Save data into Terraform state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// addDanglingRelationWarnings warns about the relations to entities that don't
+// exist, which are ignored by the traversal.
+func addDanglingRelationWarnings(diags *diag.Diagnostics, dangling []entityGraphEdge) {
+	for _, edge := range dangling {
+		diags.AddWarning(
+			"Relation to missing GitBook entity",
+			fmt.Sprintf("Property %q of GitBook entity %q (type: %q) relates to entity %q (type: %q), which doesn't exist. The relation is ignored.",
+				edge.Property, edge.FromEntityID, edge.FromType, edge.ToEntityID, edge.ToType),
+		)
+	}
+}
+
+// entityGraphNodeValue converts an entity reached in the graph into an element
+// of `nodes`, with all of its properties.
+func entityGraphNodeValue(model entityGraphDataSourceModel, node entityGraphNode, diags *diag.Diagnostics) attr.Value {
+	entityModel := entityModel{
+		OrganizationID: model.OrganizationID,
+		Properties:     types.MapNull(types.ObjectType{AttrTypes: entityPropertyAttributeTypes}),
+	}
+	entityModel.parseEntity(&node.Entity, diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	value, d := types.ObjectValue(entityGraphNodeAttributeTypes, map[string]attr.Value{
+		"id":         entityModel.ID,
+		"type":       entityModel.Type,
+		"entity_id":  entityModel.EntityID,
+		"depth":      types.Int64Value(int64(node.Depth)),
+		"properties": entityModel.Properties,
+	})
+	diags.Append(d...)
+	return value
+}
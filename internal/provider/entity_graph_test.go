@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	gitbook "github.com/GitbookIO/go-gitbook/api"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// newTestEntityGraph returns a graph of the given entities, looked up without
+// the GitBook API. Services relate to their owning team and to the service they
+// depend on. The number of times the entities of each type were listed is
+// recorded into `listings`.
+func newTestEntityGraph(t *testing.T, entities []*gitbook.Entity, listings map[string]int) *entityGraph {
+	t.Helper()
+	entitySchemas := []gitbook.EntitySchema{
+		{
+			Type: "terraform:service",
+			Properties: []gitbook.EntityPropertySchema{
+				{Name: "name", Type: "text"},
+				{Name: "owner", Type: "relation", Entity: map[string]interface{}{"type": "terraform:team"}},
+				{Name: "depends_on", Type: "relation", Entity: map[string]interface{}{"type": "terraform:service"}},
+			},
+		},
+		{
+			Type:       "terraform:team",
+			Properties: []gitbook.EntityPropertySchema{{Name: "name", Type: "text"}},
+		},
+	}
+	notFound := newTestJSONAPIError(t, 404, `{"error":{"code":404,"message":"Not found"}}`)
+
+	return &entityGraph{
+		fetchEntity: func(ctx context.Context, entityType, entityID string) (*gitbook.Entity, error) {
+			for _, entity := range entities {
+				if entity.Type == entityType && entity.EntityId == entityID {
+					return entity, nil
+				}
+			}
+			return nil, notFound
+		},
+		fetchEntities: func(ctx context.Context, entityType string) ([]gitbook.Entity, error) {
+			listings[entityType]++
+			var list []gitbook.Entity
+			for _, entity := range entities {
+				if entity.Type == entityType {
+					list = append(list, *entity)
+				}
+			}
+			return list, nil
+		},
+		relations: entityGraphRelations(entitySchemas),
+		listed:    make(map[string]map[string]gitbook.Entity),
+	}
+}
+
+func TestEntityGraphTraverse(t *testing.T) {
+	entities := []*gitbook.Entity{
+		// The API and the database depend on each other.
+		decodeTestEntity(t, `{"type":"terraform:service","entityId":"api","properties":{"owner":{"entityId":"platform"},"depends_on":{"entityId":"db"}}}`),
+		decodeTestEntity(t, `{"type":"terraform:service","entityId":"db","properties":{"owner":{"entityId":"platform"},"depends_on":{"entityId":"api"}}}`),
+		// The owner of the website doesn't exist.
+		decodeTestEntity(t, `{"type":"terraform:service","entityId":"web","properties":{"owner":{"entityId":"missing"},"depends_on":{"entityId":"api"}}}`),
+		decodeTestEntity(t, `{"type":"terraform:team","entityId":"platform","properties":{"name":"Platform"}}`),
+	}
+
+	tests := map[string]struct {
+		entityType   string
+		entityID     string
+		depth        int
+		outgoing     bool
+		incoming     bool
+		wantNodes    []string
+		wantEdges    []string
+		wantDangling []string
+	}{
+		"no depth": {
+			entityType: "terraform:service",
+			entityID:   "api",
+			depth:      0,
+			outgoing:   true,
+			incoming:   true,
+			wantNodes:  []string{"terraform:service/api@0"},
+			wantEdges:  []string{},
+		},
+		"outgoing cycle": {
+			entityType: "terraform:service",
+			entityID:   "api",
+			depth:      10,
+			outgoing:   true,
+			wantNodes:  []string{"terraform:service/api@0", "terraform:service/db@1", "terraform:team/platform@1"},
+			wantEdges: []string{
+				"terraform:service/api.depends_on->terraform:service/db",
+				"terraform:service/api.owner->terraform:team/platform",
+				"terraform:service/db.depends_on->terraform:service/api",
+				"terraform:service/db.owner->terraform:team/platform",
+			},
+		},
+		"depth cut-off": {
+			entityType:   "terraform:service",
+			entityID:     "web",
+			depth:        1,
+			outgoing:     true,
+			wantNodes:    []string{"terraform:service/web@0", "terraform:service/api@1"},
+			wantEdges:    []string{"terraform:service/web.depends_on->terraform:service/api"},
+			wantDangling: []string{"terraform:service/web.owner->terraform:team/missing"},
+		},
+		"incoming": {
+			entityType: "terraform:team",
+			entityID:   "platform",
+			depth:      1,
+			incoming:   true,
+			wantNodes:  []string{"terraform:team/platform@0", "terraform:service/api@1", "terraform:service/db@1"},
+			wantEdges: []string{
+				"terraform:service/api.owner->terraform:team/platform",
+				"terraform:service/db.owner->terraform:team/platform",
+			},
+		},
+		"both directions": {
+			entityType: "terraform:service",
+			entityID:   "api",
+			depth:      2,
+			outgoing:   true,
+			incoming:   true,
+			wantNodes: []string{
+				"terraform:service/api@0",
+				"terraform:service/db@1",
+				"terraform:service/web@1",
+				"terraform:team/platform@1",
+			},
+			wantEdges: []string{
+				"terraform:service/api.depends_on->terraform:service/db",
+				"terraform:service/api.owner->terraform:team/platform",
+				"terraform:service/db.depends_on->terraform:service/api",
+				"terraform:service/db.owner->terraform:team/platform",
+				"terraform:service/web.depends_on->terraform:service/api",
+			},
+			wantDangling: []string{"terraform:service/web.owner->terraform:team/missing"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// The result doesn't depend on the iteration order of maps.
+			for i := 0; i < 10; i++ {
+				listings := make(map[string]int)
+				graph := newTestEntityGraph(t, entities, listings)
+				nodes, edges, err := graph.Traverse(context.Background(), test.entityType, test.entityID, test.depth, test.outgoing, test.incoming)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				gotNodes := make([]string, 0, len(nodes))
+				for _, node := range nodes {
+					gotNodes = append(gotNodes, fmt.Sprintf("%s/%s@%d", node.Entity.Type, node.Entity.EntityId, node.Depth))
+				}
+				if !reflect.DeepEqual(gotNodes, test.wantNodes) {
+					t.Fatalf("expected nodes %v, got %v", test.wantNodes, gotNodes)
+				}
+				if got := formatTestEntityGraphEdges(edges); !reflect.DeepEqual(got, test.wantEdges) {
+					t.Fatalf("expected edges %v, got %v", test.wantEdges, got)
+				}
+				if got, want := formatTestEntityGraphEdges(graph.Dangling), append([]string{}, test.wantDangling...); !reflect.DeepEqual(got, want) {
+					t.Fatalf("expected dangling relations %v, got %v", test.wantDangling, got)
+				}
+				for entityType, count := range listings {
+					if count != 1 {
+						t.Fatalf("expected the entities of %q to be listed once, got %d listings", entityType, count)
+					}
+				}
+
+				var diags diag.Diagnostics
+				addDanglingRelationWarnings(&diags, graph.Dangling)
+				if diags.HasError() || diags.WarningsCount() != len(test.wantDangling) {
+					t.Fatalf("expected %d warnings, got %v", len(test.wantDangling), diags)
+				}
+			}
+		})
+	}
+}
+
+func TestEntityGraphTraverseMissingRoot(t *testing.T) {
+	graph := newTestEntityGraph(t, nil, make(map[string]int))
+	_, _, err := graph.Traverse(context.Background(), "terraform:service", "api", 1, true, true)
+	if !isNotFoundError(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func formatTestEntityGraphEdges(edges []entityGraphEdge) []string {
+	formatted := make([]string, 0, len(edges))
+	for _, edge := range edges {
+		formatted = append(formatted, fmt.Sprintf("%s/%s.%s->%s/%s", edge.FromType, edge.FromEntityID, edge.Property, edge.ToType, edge.ToEntityID))
+	}
+	return formatted
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	gitbook "github.com/GitbookIO/go-gitbook/api"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// listSchemaEntities lists every entity of an entity schema, paging through
+// the results of the GitBook API.
+func listSchemaEntities(ctx context.Context, client *gitbook.OrganizationsApiService, organizationID, entityType string) ([]gitbook.Entity, error) {
+	return queryListSchemaEntities(ctx, client, organizationID, entityType, "")
+}
+
+// queryListSchemaEntities lists the entities of an entity schema matching a
+// query of the GitBook API, e.g. `a == 'something' && b >= 10`, paging through
+// the results. Every entity is listed when the query is empty.
+func queryListSchemaEntities(ctx context.Context, client *gitbook.OrganizationsApiService, organizationID, entityType, query string) ([]gitbook.Entity, error) {
+	var entities []gitbook.Entity
+	page := ""
+	for {
+		listReq := client.ListSchemaEntities(ctx, organizationID, entityType)
+		if query != "" {
+			listReq = listReq.Query(query)
+		}
+		if page != "" {
+			listReq = listReq.Page(page)
+		}
+		list, _, err := listReq.Execute()
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, list.Items...)
+
+		if list.Next == nil || list.Next.Page == "" {
+			return entities, nil
+		}
+		page = list.Next.Page
+	}
+}
+
+// listEntitySchemas lists every entity schema of an organization, paging
+// through the results of the GitBook API.
+func listEntitySchemas(ctx context.Context, client *gitbook.OrganizationsApiService, organizationID string) ([]gitbook.EntitySchema, error) {
+	var entitySchemas []gitbook.EntitySchema
+	page := ""
+	for {
+		listReq := client.ListEntitySchemas(ctx, organizationID)
+		if page != "" {
+			listReq = listReq.Page(page)
+		}
+		list, _, err := listReq.Execute()
+		if err != nil {
+			return nil, err
+		}
+		entitySchemas = append(entitySchemas, list.Items...)
+
+		if list.Next == nil || list.Next.Page == "" {
+			return entitySchemas, nil
+		}
+		page = list.Next.Page
+	}
+}
+
+// findEntityByID finds an entity by its unique GitBook ID, which the GitBook
+// API can't look up directly. The entities of every entity schema of the
+// organization are listed until a match is found, or only those of
+// `entityType` when it's not empty. A nil entity is returned if none matches.
+func findEntityByID(ctx context.Context, client *gitbook.OrganizationsApiService, organizationID, entityType, id string) (*gitbook.Entity, error) {
+	entityTypes := []string{entityType}
+	if entityType == "" {
+		entitySchemas, err := listEntitySchemas(ctx, client, organizationID)
+		if err != nil {
+			return nil, err
+		}
+		entityTypes = make([]string, len(entitySchemas))
+		for i, entitySchema := range entitySchemas {
+			entityTypes[i] = entitySchema.Type
+		}
+	}
+
+	for _, entityType := range entityTypes {
+		tflog.Debug(ctx, "Searching entities for ID", map[string]interface{}{
+			"organization_id": organizationID,
+			"type":            entityType,
+			"id":              id,
+		})
+
+		entities, err := listSchemaEntities(ctx, client, organizationID, entityType)
+		if err != nil {
+			return nil, err
+		}
+		for _, entity := range entities {
+			if entity.Id == id {
+				return &entity, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// entityBulkChunkSize is the maximum number of entities written in a single
+// API call by bulk operations, such as force destroying an entity schema. It
+// is independent of the batching of entity resource writes, which may be
+// disabled.
+const entityBulkChunkSize = 100
+
+// deleteSchemaEntities deletes entities of an entity schema, sending at most
+// `entityBulkChunkSize` entities per API call.
+func deleteSchemaEntities(ctx context.Context, client *gitbook.OrganizationsApiService, organizationID, entityType string, entityIDs []string) error {
+	for start := 0; start < len(entityIDs); start += entityBulkChunkSize {
+		end := start + entityBulkChunkSize
+		if end > len(entityIDs) {
+			end = len(entityIDs)
+		}
+		chunk := entityIDs[start:end]
+
+		tflog.Debug(ctx, fmt.Sprintf("Deleting entities %d to %d of %d", start+1, end, len(entityIDs)), map[string]interface{}{
+			"organization_id": organizationID,
+			"type":            entityType,
+		})
+
+		opts := gitbook.UpsertSchemaEntitiesRequest{
+			Entities: []gitbook.UpsertEntity{},
+			Delete: &gitbook.UpsertSchemaEntitiesRequestDelete{
+				ArrayOfString: &chunk,
+			},
+		}
+		_, err := client.UpsertSchemaEntities(ctx, organizationID, entityType).UpsertSchemaEntitiesRequest(opts).Execute()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
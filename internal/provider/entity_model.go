@@ -11,12 +11,14 @@ import (
 )
 
 type entityModel struct {
-	ID             types.String `tfsdk:"id"`
-	OrganizationID types.String `tfsdk:"organization_id"`
-	Type           types.String `tfsdk:"type"`
-	EntityID       types.String `tfsdk:"entity_id"`
-	Properties     types.Map    `tfsdk:"properties"`
-	URLs           types.Object `tfsdk:"urls"`
+	ID               types.String `tfsdk:"id"`
+	OrganizationID   types.String `tfsdk:"organization_id"`
+	Type             types.String `tfsdk:"type"`
+	EntityID         types.String `tfsdk:"entity_id"`
+	Properties       types.Map    `tfsdk:"properties"`
+	RemoteProperties types.Map    `tfsdk:"remote_properties"`
+	URLs             types.Object `tfsdk:"urls"`
+	AdoptExisting    types.Bool   `tfsdk:"adopt_existing"`
 }
 
 type entityProperty struct {
@@ -48,6 +50,11 @@ var entityURLsAttributeTypes = map[string]attr.Type{
 }
 
 // parseEntity merges an Entity from GitBook into a Terraform model.
+//
+// The complete set of properties returned by GitBook is stored in
+// `remote_properties`, whereas `properties` only tracks the keys that are
+// already known to the model (e.g. configured by the user). When the model has
+// no properties yet (e.g. on import), all remote properties are used.
 func (m *entityModel) parseEntity(entity *gitbook.Entity, diags *diag.Diagnostics) {
 	m.ID = types.StringValue(entity.Id)
 	m.Type = types.StringValue(entity.Type)
@@ -99,15 +106,16 @@ func (m *entityModel) parseEntity(entity *gitbook.Entity, diags *diag.Diagnostic
 			}
 			propsMap[propName] = numberValue
 		case *bool:
-			numberValue, d := types.ObjectValue(entityPropertyAttributeTypes, map[string]attr.Value{
-				"boolean": types.BoolPointerValue(actual),
-				"string":  types.StringNull(),
-				"number":  types.NumberNull(),
+			boolValue, d := types.ObjectValue(entityPropertyAttributeTypes, map[string]attr.Value{
+				"boolean":  types.BoolPointerValue(actual),
+				"string":   types.StringNull(),
+				"number":   types.NumberNull(),
+				"relation": types.ObjectNull(entityRelationPropAttributeTypes),
 			})
 			if d.HasError() {
 				diags.Append(d...)
 			}
-			propsMap[propName] = numberValue
+			propsMap[propName] = boolValue
 		case *gitbook.UpsertEntityPropertiesValueOneOf:
 			relation, d := types.ObjectValue(entityRelationPropAttributeTypes, map[string]attr.Value{
 				"entity_id": types.StringValue(actual.EntityId),
@@ -138,7 +146,28 @@ func (m *entityModel) parseEntity(entity *gitbook.Entity, diags *diag.Diagnostic
 		return
 	}
 
-	props, d := types.MapValue(types.ObjectType{AttrTypes: entityPropertyAttributeTypes}, propsMap)
+	remoteProps, d := types.MapValue(types.ObjectType{AttrTypes: entityPropertyAttributeTypes}, propsMap)
+	if d.HasError() {
+		diags.Append(d...)
+		return
+	}
+	m.RemoteProperties = remoteProps
+
+	if m.Properties.IsNull() || m.Properties.IsUnknown() {
+		m.Properties = remoteProps
+		return
+	}
+
+	// Only keep the properties that are tracked by the model, so that
+	// properties added outside of Terraform don't show up as a diff. Tracked
+	// properties missing from GitBook are dropped, so they show up as drift.
+	trackedPropsMap := make(map[string]attr.Value, len(m.Properties.Elements()))
+	for propName := range m.Properties.Elements() {
+		if propValue, ok := propsMap[propName]; ok {
+			trackedPropsMap[propName] = propValue
+		}
+	}
+	props, d := types.MapValue(types.ObjectType{AttrTypes: entityPropertyAttributeTypes}, trackedPropsMap)
 	if d.HasError() {
 		diags.Append(d...)
 		return
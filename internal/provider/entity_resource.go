@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	gitbook "github.com/GitbookIO/go-gitbook/api"
 	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
@@ -18,6 +19,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 func NewEntityResource() resource.Resource {
@@ -25,7 +27,8 @@ func NewEntityResource() resource.Resource {
 }
 
 type entityResource struct {
-	client *gitbook.OrganizationsApiService
+	client  *gitbook.OrganizationsApiService
+	batcher *entityBatcher
 }
 
 func (r *entityResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -35,6 +38,7 @@ func (r *entityResource) Metadata(ctx context.Context, req resource.MetadataRequ
 func (r *entityResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Entity resource",
+		Version:             entityResourceSchemaVersion,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -114,6 +118,37 @@ func (r *entityResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					},
 				},
 			},
+			"remote_properties": schema.MapNestedAttribute{
+				Computed: true,
+				MarkdownDescription: "Map of all properties of the entity as returned by GitBook, including properties " +
+					"that are not managed by this resource.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"string": schema.StringAttribute{
+							Computed: true,
+						},
+						"number": schema.NumberAttribute{
+							Computed: true,
+						},
+						"boolean": schema.BoolAttribute{
+							Computed: true,
+						},
+						"relation": schema.SingleNestedAttribute{
+							Computed: true,
+							Attributes: map[string]schema.Attribute{
+								"entity_id": schema.StringAttribute{
+									Computed: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Allow the creation of the resource to take over an entity that already exists in GitBook. " +
+					"By default, creating an entity that already exists fails, and it must be imported instead.",
+			},
 			"urls": schema.SingleNestedAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.Object{
@@ -135,18 +170,24 @@ func (r *entityResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*gitbook.APIClient)
+	client, ok := req.ProviderData.(*gitBookClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *gitbook.APIClient, got: %T. Please report this issue to GitBook.", req.ProviderData),
+			fmt.Sprintf("Expected *gitBookClient, got: %T. Please report this issue to GitBook.", req.ProviderData),
 		)
 
 		return
 	}
 
+	client.addTokenError(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	r.client = client.OrganizationsApi
+	r.batcher = client.entityBatcher
 }
 
 func (r *entityResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -163,32 +204,70 @@ func (r *entityResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	opts := gitbook.UpsertSchemaEntitiesRequest{
-		Entities: []gitbook.UpsertEntity{*entity},
-	}
 	organizationID := model.OrganizationID.ValueString()
 	entityType := model.Type.ValueString()
 	entityID := model.EntityID.ValueString()
 
-	// Create entity via the GitBook API.
-	_, err := r.client.UpsertSchemaEntities(ctx, organizationID, entityType).UpsertSchemaEntitiesRequest(opts).Execute()
+	// Refuse to overwrite an entity that isn't managed by this resource, unless
+	// explicitly adopting it.
+	_, _, err := r.client.GetEntity(ctx, organizationID, entityType, entityID).Execute()
+	switch {
+	case err == nil && !model.AdoptExisting.ValueBool():
+		addEntityExistsError(&resp.Diagnostics, organizationID, entityType, entityID)
+		return
+	case err == nil:
+		tflog.Info(ctx, "Adopting existing GitBook entity", map[string]interface{}{
+			"organization_id": organizationID,
+			"type":            entityType,
+			"entity_id":       entityID,
+		})
+	case !isNotFoundError(err):
+		addAPIError(
+			&resp.Diagnostics,
+			"Error reading GitBook entity",
+			fmt.Sprintf("Could not check whether GitBook entity %q already exists", entityID),
+			err,
+		)
+		return
+	}
+
+	// Create entity via the GitBook API, batched with other entity writes.
+	err = r.batcher.Upsert(ctx, organizationID, entityType, *entity)
 	if err != nil {
-		errMessage := parseErrorMessage(err)
-		resp.Diagnostics.AddError(
+		addPropertiesAPIError(
+			&resp.Diagnostics,
+			model.Properties,
 			"Error creating GitBook entity",
-			fmt.Sprintf("Could not create GitBook entity: %v", errMessage),
+			"Could not create GitBook entity",
+			err,
 		)
 		return
 	}
 
+	// Save the identifying attributes right away, so that the entity is
+	// tracked even if reading it back fails.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), organizationID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), entityType)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("entity_id"), entityID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// The HTTP response when creating an entity returns `204 No Content`,
-	// so we need to fetch the entity to get its (computed) properties.
-	created, _, err := r.client.GetEntity(ctx, organizationID, entityType, entityID).Execute()
+	// so we need to fetch the entity to get its (computed) properties. Wait
+	// for GitBook to return the written properties, as reads can lag behind.
+	created, err := waitForConsistency(ctx, func(ctx context.Context) (*gitbook.Entity, error) {
+		created, _, err := r.client.GetEntity(ctx, organizationID, entityType, entityID).Execute()
+		return created, err
+	}, func(created *gitbook.Entity) bool {
+		return upsertEntityMatches(*entity, created)
+	})
 	if err != nil {
-		errMessage := parseErrorMessage(err)
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Error reading created GitBook entity",
-			fmt.Sprintf("Could not read updated GitBook entity: %v", errMessage),
+			"Could not read created GitBook entity",
+			err,
 		)
 		return
 	}
@@ -215,11 +294,18 @@ func (r *entityResource) Read(ctx context.Context, req resource.ReadRequest, res
 	entityID := state.EntityID.ValueString()
 
 	entity, _, err := r.client.GetEntity(ctx, organizationID, entityType, entityID).Execute()
+	if isNotFoundError(err) {
+		// The entity was deleted outside of Terraform, so remove it from state
+		// to have it recreated.
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		errMessage := parseErrorMessage(err)
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Error reading GitBook entity",
-			fmt.Sprintf("Could not read GitBook entity: %v", errMessage),
+			"Could not read GitBook entity",
+			err,
 		)
 		return
 	}
@@ -247,32 +333,38 @@ func (r *entityResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	opts := gitbook.UpsertSchemaEntitiesRequest{
-		Entities: []gitbook.UpsertEntity{*entity},
-	}
 	organizationID := model.OrganizationID.ValueString()
 	entityType := model.Type.ValueString()
 	entityID := model.EntityID.ValueString()
 
-	// Create entity via the GitBook API.
-	_, err := r.client.UpsertSchemaEntities(ctx, organizationID, entityType).UpsertSchemaEntitiesRequest(opts).Execute()
+	// Update entity via the GitBook API, batched with other entity writes.
+	err := r.batcher.Upsert(ctx, organizationID, entityType, *entity)
 	if err != nil {
-		errMessage := parseErrorMessage(err)
-		resp.Diagnostics.AddError(
+		addPropertiesAPIError(
+			&resp.Diagnostics,
+			model.Properties,
 			"Error updating GitBook entity",
-			fmt.Sprintf("Could not update GitBook entity: %v", errMessage),
+			"Could not update GitBook entity",
+			err,
 		)
 		return
 	}
 
 	// The HTTP response when creating an entity returns `204 No Content`,
-	// so we need to fetch the entity to get its (computed) properties.
-	created, _, err := r.client.GetEntity(ctx, organizationID, entityType, entityID).Execute()
+	// so we need to fetch the entity to get its (computed) properties. Wait
+	// for GitBook to return the written properties, as reads can lag behind.
+	created, err := waitForConsistency(ctx, func(ctx context.Context) (*gitbook.Entity, error) {
+		created, _, err := r.client.GetEntity(ctx, organizationID, entityType, entityID).Execute()
+		return created, err
+	}, func(created *gitbook.Entity) bool {
+		return upsertEntityMatches(*entity, created)
+	})
 	if err != nil {
-		errMessage := parseErrorMessage(err)
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Error reading updated GitBook entity",
-			fmt.Sprintf("Could not read updated GitBook entity: %v", errMessage),
+			"Could not read updated GitBook entity",
+			err,
 		)
 		return
 	}
@@ -299,26 +391,80 @@ func (r *entityResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	organizationID := model.OrganizationID.ValueString()
 	entityType := model.Type.ValueString()
 
-	opts := gitbook.UpsertSchemaEntitiesRequest{
-		Entities: []gitbook.UpsertEntity{},
-		Delete: &gitbook.UpsertSchemaEntitiesRequestDelete{
-			ArrayOfString: &[]string{entityID},
-		},
+	// Delete entity via the GitBook API, batched with other entity writes.
+	err := r.batcher.Delete(ctx, organizationID, entityType, entityID)
+	// The entity may already have been deleted along with its entity schema
+	// (see `force_destroy`), which is fine.
+	if err != nil && !isNotFoundError(err) {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error deleting GitBook entity",
+			"Could not delete GitBook entity",
+			err,
+		)
 	}
+}
 
-	// Delete entity via the GitBook API.
-	_, err := r.client.UpsertSchemaEntities(ctx, organizationID, entityType).UpsertSchemaEntitiesRequest(opts).Execute()
-	if err != nil {
-		errMessage := parseErrorMessage(err)
+// ImportState imports an entity using an ID of the form
+// `<organization_id>/<type>/<entity_id>`. The entity ID may itself contain
+// slashes, e.g. when it's an ARN.
+func (r *entityResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	organizationID, rest, _ := strings.Cut(req.ID, "/")
+	entityType, entityID, ok := strings.Cut(rest, "/")
+	if !ok || organizationID == "" || entityType == "" || entityID == "" {
 		resp.Diagnostics.AddError(
-			"Error deleting GitBook entity",
-			fmt.Sprintf("Could not delete GitBook entity: %v", errMessage),
+			"Invalid GitBook entity import ID",
+			fmt.Sprintf("Expected an import ID of the form `<organization_id>/<type>/<entity_id>`, got: %q.", req.ID),
 		)
+		return
 	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), organizationID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), entityType)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("entity_id"), entityID)...)
 }
 
-func (r *entityResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+func addEntityExistsError(diags *diag.Diagnostics, organizationID, entityType, entityID string) {
+	diags.AddAttributeError(
+		path.Root("entity_id"),
+		"GitBook entity already exists",
+		fmt.Sprintf("The GitBook entity %q of entity schema %q already exists in organization %q, and may be managed elsewhere. ", entityID, entityType, organizationID)+
+			fmt.Sprintf("Import it with the ID `%s/%s/%s` to manage it with this resource, ", organizationID, entityType, entityID)+
+			"or set `adopt_existing = true` to deliberately take it over.",
+	)
+}
+
+// upsertEntityMatches reports whether an entity read from GitBook holds all the
+// property values of an upserted entity.
+func upsertEntityMatches(upserted gitbook.UpsertEntity, entity *gitbook.Entity) bool {
+	if entity == nil {
+		return false
+	}
+	for propName, upsertedValue := range upserted.Properties {
+		value, ok := entity.Properties[propName]
+		if !ok {
+			return false
+		}
+		switch expected := upsertedValue.GetActualInstance().(type) {
+		case *string:
+			if value.String == nil || *value.String != *expected {
+				return false
+			}
+		case *bool:
+			if value.Bool == nil || *value.Bool != *expected {
+				return false
+			}
+		case *float32:
+			if value.Float32 == nil || *value.Float32 != *expected {
+				return false
+			}
+		case *gitbook.UpsertEntityPropertiesValueOneOf:
+			if value.UpsertEntityPropertiesValueOneOf == nil || value.UpsertEntityPropertiesValueOneOf.EntityId != expected.EntityId {
+				return false
+			}
+		}
+	}
+	return true
 }
 
 func parseUpsertEntityFromModel(ctx context.Context, model entityModel, diags *diag.Diagnostics) *gitbook.UpsertEntity {
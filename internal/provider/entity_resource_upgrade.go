@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// entityResourceSchemaVersion is the current version of the `gitbook_entity`
+// resource schema. It must be incremented, and a state upgrader added, for
+// every change to the shape of the resource state.
+const entityResourceSchemaVersion = 1
+
+// entityModelV0 is the state model of version 0 of the `gitbook_entity`
+// resource schema, which had no `remote_properties` attribute.
+type entityModelV0 struct {
+	ID             types.String `tfsdk:"id"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+	Type           types.String `tfsdk:"type"`
+	EntityID       types.String `tfsdk:"entity_id"`
+	Properties     types.Map    `tfsdk:"properties"`
+	URLs           types.Object `tfsdk:"urls"`
+}
+
+func (r *entityResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   entityResourceSchemaV0(),
+			StateUpgrader: upgradeEntityStateV0,
+		},
+	}
+}
+
+// upgradeEntityStateV0 upgrades state from version 0 to version 1, which added
+// the computed `remote_properties` attribute. It is seeded with the prior
+// properties until the next refresh populates it from GitBook.
+func upgradeEntityStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior entityModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upgraded := entityModel{
+		ID:               prior.ID,
+		OrganizationID:   prior.OrganizationID,
+		Type:             prior.Type,
+		EntityID:         prior.EntityID,
+		Properties:       prior.Properties,
+		RemoteProperties: prior.Properties,
+		URLs:             prior.URLs,
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
+
+func entityResourceSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"organization_id": schema.StringAttribute{
+				Required: true,
+			},
+			"type": schema.StringAttribute{
+				Required: true,
+			},
+			"entity_id": schema.StringAttribute{
+				Required: true,
+			},
+			"properties": schema.MapNestedAttribute{
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"string": schema.StringAttribute{
+							Optional: true,
+						},
+						"number": schema.NumberAttribute{
+							Optional: true,
+						},
+						"boolean": schema.BoolAttribute{
+							Optional: true,
+						},
+						"relation": schema.SingleNestedAttribute{
+							Optional: true,
+							Attributes: map[string]schema.Attribute{
+								"entity_id": schema.StringAttribute{
+									Required: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			"urls": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"location": schema.StringAttribute{
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+}
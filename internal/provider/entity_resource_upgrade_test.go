@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestUpgradeEntityStateV0(t *testing.T) {
+	ctx := context.Background()
+	state := upgradeStateFixture(t, &entityResource{}, 0, "testdata/entity_state_v0.json")
+
+	var model entityModel
+	if diags := state.Get(ctx, &model); diags.HasError() {
+		t.Fatalf("decoding upgraded state: %v", diags)
+	}
+
+	if got := model.ID.ValueString(); got != "ent_4Me7JapjYF3sgxrFoKxP" {
+		t.Errorf("expected id %q, got %q", "ent_4Me7JapjYF3sgxrFoKxP", got)
+	}
+	if got := model.EntityID.ValueString(); got != "example-id" {
+		t.Errorf("expected entity_id %q, got %q", "example-id", got)
+	}
+	if got := len(model.Properties.Elements()); got != 3 {
+		t.Errorf("expected 3 properties, got %d", got)
+	}
+	if !model.RemoteProperties.Equal(model.Properties) {
+		t.Errorf("expected remote_properties to be seeded with properties, got %s", model.RemoteProperties)
+	}
+	if !model.AdoptExisting.IsNull() {
+		t.Errorf("expected adopt_existing to be null, got %s", model.AdoptExisting)
+	}
+
+	remoteProps := make(map[string]entityProperty)
+	if diags := model.RemoteProperties.ElementsAs(ctx, &remoteProps, false); diags.HasError() {
+		t.Fatalf("decoding remote_properties: %v", diags)
+	}
+	if got := remoteProps["name"].String.ValueString(); got != "Example" {
+		t.Errorf("expected remote property name %q, got %q", "Example", got)
+	}
+	var owner entityRelationProperty
+	if diags := remoteProps["owner"].Relation.As(ctx, &owner, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("decoding relation: %v", diags)
+	}
+	if !owner.EntityID.Equal(types.StringValue("team-a")) {
+		t.Errorf("expected remote property owner to relate to %q, got %s", "team-a", owner.EntityID)
+	}
+}
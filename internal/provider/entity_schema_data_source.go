@@ -5,10 +5,11 @@ import (
 	"fmt"
 
 	gitbook "github.com/GitbookIO/go-gitbook/api"
-	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func NewEntitySchemaDataSource() datasource.DataSource {
@@ -20,6 +21,74 @@ type entitySchemaDataSource struct {
 	client *gitbook.OrganizationsApiService
 }
 
+// entitySchemaDataSourceModel is the subset of `entitySchemaModel` exposed by
+// the data source, which has no resource-only attributes.
+type entitySchemaDataSourceModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+	Type           types.String `tfsdk:"type"`
+	Title          types.Object `tfsdk:"title"`
+	Properties     types.Map    `tfsdk:"properties"`
+}
+
+// entitySchemaDataSourcePropertyAttributeTypes are the attributes of entity
+// schema properties, without the resource-only `renamed_from`.
+var entitySchemaDataSourcePropertyAttributeTypes = map[string]attr.Type{
+	"title":       types.StringType,
+	"description": types.StringType,
+	"type":        types.StringType,
+	"order":       types.Int64Type,
+	"entity": types.ObjectType{
+		AttrTypes: entitySchemaEntityPropAttributeTypes,
+	},
+}
+
+// parseEntitySchema merges an entity schema from GitBook into the data source
+// model. Every property gets an `order`, following the order of the
+// properties returned by GitBook.
+func (m *entitySchemaDataSourceModel) parseEntitySchema(entitySchema *gitbook.EntitySchema, diags *diag.Diagnostics) {
+	model := entitySchemaModel{
+		Properties: types.MapNull(types.ObjectType{AttrTypes: entitySchemaPropertyAttributeTypes}),
+	}
+	model.parseEntitySchema(entitySchema, diags)
+	if diags.HasError() {
+		return
+	}
+
+	properties := make(map[string]attr.Value, len(model.Properties.Elements()))
+	for i, property := range entitySchema.Properties {
+		prop, ok := model.Properties.Elements()[property.Name].(types.Object)
+		if !ok {
+			continue
+		}
+		attributes := make(map[string]attr.Value, len(prop.Attributes()))
+		for name, value := range prop.Attributes() {
+			if _, ok := entitySchemaDataSourcePropertyAttributeTypes[name]; ok {
+				attributes[name] = value
+			}
+		}
+		attributes["order"] = types.Int64Value(int64(i + 1))
+
+		prop, d := types.ObjectValue(entitySchemaDataSourcePropertyAttributeTypes, attributes)
+		if d.HasError() {
+			diags.Append(d...)
+			return
+		}
+		properties[property.Name] = prop
+	}
+
+	propsMapValue, d := types.MapValue(types.ObjectType{
+		AttrTypes: entitySchemaDataSourcePropertyAttributeTypes,
+	}, properties)
+	if d.HasError() {
+		diags.Append(d...)
+		return
+	}
+
+	m.Type = model.Type
+	m.Title = model.Title
+	m.Properties = propsMapValue
+}
+
 func (d *entitySchemaDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_entity_schema"
 }
@@ -30,51 +99,65 @@ func (d *entitySchemaDataSource) Schema(ctx context.Context, req datasource.Sche
 		MarkdownDescription: "Entity schema data source",
 
 		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the organization that owns the entity schema.",
+			},
 			"type": schema.StringAttribute{
-				Required: true,
+				Required:            true,
+				MarkdownDescription: "The type of the entity schema.",
 			},
-			"title": schema.StringAttribute{
-				Required: true,
+			"title": schema.SingleNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The title of the entity schema.",
+				Attributes: map[string]schema.Attribute{
+					"singular": schema.StringAttribute{
+						Computed: true,
+					},
+					"plural": schema.StringAttribute{
+						Computed: true,
+					},
+				},
 			},
-			"properties": schema.SetNestedAttribute{
-				Required: true,
-				NestedObject: schema.NestedAttributeObject{
+			"properties": entitySchemaPropertiesDataSourceAttribute(),
+		},
+	}
+}
+
+// entitySchemaPropertiesDataSourceAttribute is the computed `properties`
+// attribute of data sources reading entity schemas.
+func entitySchemaPropertiesDataSourceAttribute() schema.MapNestedAttribute {
+	return schema.MapNestedAttribute{
+		Computed:            true,
+		MarkdownDescription: "The properties of the entity schema, where each key is the name of the property.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"title": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The title of the property.",
+				},
+				"description": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The description of the property.",
+				},
+				"type": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The type of the property. One of `text`, `number`, `boolean`, `date`, or `relation`.",
+				},
+				"order": schema.Int64Attribute{
+					Computed:            true,
+					MarkdownDescription: "The position of the property when displayed in GitBook, starting at 1.",
+				},
+				"entity": schema.SingleNestedAttribute{
+					Computed:            true,
+					MarkdownDescription: "The entity schema the property relates to, when type is `relation`.",
 					Attributes: map[string]schema.Attribute{
-						"name": schema.StringAttribute{
-							Required: true,
-						},
-						"title": schema.SingleNestedAttribute{
-							Required: true,
-							Attributes: map[string]schema.Attribute{
-								"singular": schema.StringAttribute{
-									Required: true,
-								},
-								"plural": schema.StringAttribute{
-									Required: true,
-								},
-							},
-						},
-						"description": schema.StringAttribute{
-							Optional: true,
-						},
 						"type": schema.StringAttribute{
-							Required: true,
-						},
-
-						// Used when type is `relation`
-						"entity": schema.SingleNestedAttribute{
-							Optional: true,
-							Attributes: map[string]schema.Attribute{
-								"type": schema.StringAttribute{
-									Required: true,
-								},
-							},
+							Computed:            true,
+							MarkdownDescription: "The type of the related entity schema.",
 						},
 					},
 				},
-				Validators: []validator.Set{
-					setvalidator.ValueSetsAre(setvalidator.SizeAtLeast(1)),
-				},
 			},
 		},
 	}
@@ -86,21 +169,26 @@ func (d *entitySchemaDataSource) Configure(ctx context.Context, req datasource.C
 		return
 	}
 
-	client, ok := req.ProviderData.(*gitbook.APIClient)
+	client, ok := req.ProviderData.(*gitBookClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *gitbook.APIClient, got: %T. Please report this issue to GitBook.", req.ProviderData),
+			fmt.Sprintf("Expected *gitBookClient, got: %T. Please report this issue to GitBook.", req.ProviderData),
 		)
 
 		return
 	}
 
+	client.addTokenError(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	d.client = client.OrganizationsApi
 }
 
 func (d *entitySchemaDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var model entitySchemaModel
+	var model entitySchemaDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -112,10 +200,11 @@ func (d *entitySchemaDataSource) Read(ctx context.Context, req datasource.ReadRe
 	// Fetch the entity schema via the GitBook API.
 	entitySchema, _, err := d.client.GetEntitySchema(ctx, organizationID, entityType).Execute()
 	if err != nil {
-		errMessage := parseErrorMessage(err)
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Error reading GitBook entity schema",
-			fmt.Sprintf("Could not fetch GitBook entity schema (organization: %q, type: %q): %v", organizationID, entityType, errMessage),
+			fmt.Sprintf("Could not fetch GitBook entity schema (organization: %q, type: %q)", organizationID, entityType),
+			err,
 		)
 		return
 	}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	gitbook "github.com/GitbookIO/go-gitbook/api"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEntitySchemaDataSourceParseEntitySchema(t *testing.T) {
+	ctx := context.Background()
+	description := "The team owning the service"
+	entitySchema := &gitbook.EntitySchema{
+		Type: "terraform:service",
+		Title: gitbook.EntityRawSchemaTitle{
+			Singular: "Service",
+			Plural:   "Services",
+		},
+		Properties: []gitbook.EntityPropertySchema{
+			{Name: "name", Title: "Name", Type: "text"},
+			{Name: "owner", Title: "Owner", Description: &description, Type: "relation", Entity: map[string]interface{}{"type": "terraform:team"}},
+			{Name: "created_at", Title: "Created at", Type: "date"},
+		},
+	}
+
+	var diags diag.Diagnostics
+	parsed := entitySchemaDataSourceModel{
+		OrganizationID: types.StringValue("org"),
+		Type:           types.StringValue("terraform:service"),
+	}
+	parsed.parseEntitySchema(entitySchema, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	// The model must round-trip through the state of the data source schema.
+	var schemaResp datasource.SchemaResponse
+	(&entitySchemaDataSource{}).Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if d := state.Set(ctx, &parsed); d.HasError() {
+		t.Fatalf("setting state: %v", d)
+	}
+	var model entitySchemaDataSourceModel
+	if d := state.Get(ctx, &model); d.HasError() {
+		t.Fatalf("reading state: %v", d)
+	}
+
+	if got := model.OrganizationID.ValueString(); got != "org" {
+		t.Errorf("expected organization_id %q, got %q", "org", got)
+	}
+
+	if got := model.Type.ValueString(); got != "terraform:service" {
+		t.Errorf("expected type %q, got %q", "terraform:service", got)
+	}
+	var title entitySchemaTitle
+	if d := model.Title.As(ctx, &title, basetypes.ObjectAsOptions{}); d.HasError() {
+		t.Fatalf("decoding title: %v", d)
+	}
+	if title.Singular.ValueString() != "Service" || title.Plural.ValueString() != "Services" {
+		t.Errorf("expected title Service/Services, got %s/%s", title.Singular, title.Plural)
+	}
+
+	props := make(map[string]types.Object)
+	if d := model.Properties.ElementsAs(ctx, &props, false); d.HasError() {
+		t.Fatalf("decoding properties: %v", d)
+	}
+	wantOrders := map[string]int64{"name": 1, "owner": 2, "created_at": 3}
+	if len(props) != len(wantOrders) {
+		t.Errorf("expected %d properties, got %d", len(wantOrders), len(props))
+	}
+	for name, wantOrder := range wantOrders {
+		prop, ok := props[name]
+		if !ok {
+			t.Errorf("expected property %q", name)
+			continue
+		}
+		if _, ok := prop.Attributes()["renamed_from"]; ok {
+			t.Errorf("property %q: expected no renamed_from attribute", name)
+		}
+		if got := prop.Attributes()["order"]; !got.Equal(types.Int64Value(wantOrder)) {
+			t.Errorf("property %q: expected order %d, got %s", name, wantOrder, got)
+		}
+	}
+
+	owner := props["owner"].Attributes()
+	if got := owner["type"]; !got.Equal(types.StringValue("relation")) {
+		t.Errorf("expected owner type %q, got %s", "relation", got)
+	}
+	if got := owner["description"]; !got.Equal(types.StringValue(description)) {
+		t.Errorf("expected owner description %q, got %s", description, got)
+	}
+	entity, ok := owner["entity"].(types.Object)
+	if !ok || !entity.Attributes()["type"].Equal(types.StringValue("terraform:team")) {
+		t.Errorf("expected owner to relate to %q, got %s", "terraform:team", owner["entity"])
+	}
+	if got := props["name"].Attributes()["entity"]; !got.IsNull() {
+		t.Errorf("expected name entity to be null, got %s", got)
+	}
+}
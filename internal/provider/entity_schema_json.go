@@ -0,0 +1,339 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	gitbook "github.com/GitbookIO/go-gitbook/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// jsonSchemaDraft is the JSON Schema dialect of rendered documents.
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchemaProperty is the subset of JSON Schema keywords that can be mapped
+// to and from an entity schema property.
+type jsonSchemaProperty struct {
+	Type        interface{} `json:"type,omitempty"`
+	Title       string      `json:"title,omitempty"`
+	Description *string     `json:"description,omitempty"`
+	Format      string      `json:"format,omitempty"`
+	Ref         string      `json:"$ref,omitempty"`
+	Deprecated  bool        `json:"deprecated,omitempty"`
+}
+
+// jsonSchemaDocument is a JSON Schema document rendered from an entity schema.
+type jsonSchemaDocument struct {
+	Schema     string                      `json:"$schema"`
+	ID         string                      `json:"$id"`
+	Title      string                      `json:"title"`
+	Type       string                      `json:"type"`
+	Properties orderedJSONSchemaProperties `json:"properties"`
+}
+
+// orderedJSONSchemaProperties are JSON Schema properties, marshalled in order.
+type orderedJSONSchemaProperties []namedJSONSchemaProperty
+
+type namedJSONSchemaProperty struct {
+	Name     string
+	Property jsonSchemaProperty
+}
+
+func (o orderedJSONSchemaProperties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, prop := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(prop.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(prop.Property)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// jsonSchemaStructuralKeywords are JSON Schema keywords describing structures
+// that entity schema properties can't represent.
+var jsonSchemaStructuralKeywords = map[string]bool{
+	"allOf":                true,
+	"anyOf":                true,
+	"oneOf":                true,
+	"not":                  true,
+	"if":                   true,
+	"then":                 true,
+	"else":                 true,
+	"items":                true,
+	"prefixItems":          true,
+	"properties":           true,
+	"patternProperties":    true,
+	"additionalProperties": true,
+}
+
+// jsonSchemaPropertyKeywords are the JSON Schema keywords mapped to an entity
+// schema property. Any other keyword is ignored.
+var jsonSchemaPropertyKeywords = map[string]bool{
+	"type":        true,
+	"title":       true,
+	"description": true,
+	"format":      true,
+	"$ref":        true,
+	"$comment":    true,
+}
+
+// entitySchemaPropertiesFromJSONSchema maps the properties of a JSON Schema
+// document to entity schema properties:
+//
+//   - `string` maps to `text`, or `date` with `format: date-time`.
+//   - `number` and `integer` map to `number`.
+//   - `boolean` maps to `boolean`.
+//   - `$ref` to a `terraform:` entity schema type maps to `relation`.
+//
+// Properties are ordered as they appear in the document. Constructs that can't
+// be mapped are reported as errors, and ignored keywords as warnings.
+func entitySchemaPropertiesFromJSONSchema(document string, diags *diag.Diagnostics) types.Map {
+	attrPath := path.Root("json_schema")
+	nullProps := types.MapNull(types.ObjectType{AttrTypes: entitySchemaPropertyAttributeTypes})
+
+	var root struct {
+		Type       interface{}     `json:"type"`
+		Properties json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal([]byte(document), &root); err != nil {
+		diags.AddAttributeError(attrPath, "Invalid JSON Schema", "The JSON Schema document could not be parsed: "+err.Error())
+		return nullProps
+	}
+	if rootType, ok := jsonSchemaSingleType(root.Type); root.Type != nil && (!ok || rootType != "object") {
+		diags.AddAttributeError(attrPath, "Unsupported JSON Schema", fmt.Sprintf("The JSON Schema document must describe an `object`, got: %v.", root.Type))
+		return nullProps
+	}
+
+	var names []string
+	var rawProps map[string]json.RawMessage
+	if len(root.Properties) > 0 {
+		var err error
+		names, rawProps, err = decodeOrderedJSONObject(root.Properties)
+		if err != nil {
+			diags.AddAttributeError(attrPath, "Invalid JSON Schema", "The `properties` of the JSON Schema document are invalid: "+err.Error()+".")
+			return nullProps
+		}
+	}
+	if len(names) == 0 {
+		diags.AddAttributeError(attrPath, "Unsupported JSON Schema", "The JSON Schema document must have at least one property in `properties`.")
+		return nullProps
+	}
+
+	props := make(map[string]attr.Value, len(names))
+	for i, name := range names {
+		propValue, ok := entitySchemaPropertyFromJSONSchema(name, rawProps[name], i+1, diags)
+		if ok {
+			props[name] = propValue
+		}
+	}
+	if diags.HasError() {
+		return nullProps
+	}
+
+	propsValue, d := types.MapValue(types.ObjectType{AttrTypes: entitySchemaPropertyAttributeTypes}, props)
+	diags.Append(d...)
+	return propsValue
+}
+
+func entitySchemaPropertyFromJSONSchema(name string, raw json.RawMessage, order int, diags *diag.Diagnostics) (attr.Value, bool) {
+	attrPath := path.Root("json_schema")
+
+	var keywords map[string]json.RawMessage
+	var prop jsonSchemaProperty
+	if json.Unmarshal(raw, &keywords) != nil || json.Unmarshal(raw, &prop) != nil {
+		diags.AddAttributeError(attrPath, "Invalid JSON Schema", fmt.Sprintf("Property %q must be a JSON Schema object.", name))
+		return nil, false
+	}
+
+	var ignored []string
+	for keyword := range keywords {
+		switch {
+		case jsonSchemaStructuralKeywords[keyword]:
+			diags.AddAttributeError(attrPath, "Unsupported JSON Schema", fmt.Sprintf("Property %q uses `%s`, which can't be mapped to an entity schema property.", name, keyword))
+			return nil, false
+		case !jsonSchemaPropertyKeywords[keyword]:
+			ignored = append(ignored, "`"+keyword+"`")
+		}
+	}
+	if len(ignored) > 0 {
+		sort.Strings(ignored)
+		diags.AddAttributeWarning(attrPath, "Ignored JSON Schema keywords", fmt.Sprintf("Property %q uses %s, which entity schema properties don't support.", name, strings.Join(ignored, ", ")))
+	}
+
+	propType, entity, err := entitySchemaPropertyTypeFromJSONSchema(prop)
+	if err != nil {
+		diags.AddAttributeError(attrPath, "Unsupported JSON Schema", fmt.Sprintf("Property %q %s.", name, err))
+		return nil, false
+	}
+
+	title := prop.Title
+	if title == "" {
+		title = name
+	}
+
+	propValue, d := types.ObjectValue(entitySchemaPropertyAttributeTypes, map[string]attr.Value{
+		"title":        types.StringValue(title),
+		"description":  types.StringPointerValue(prop.Description),
+		"type":         types.StringValue(propType),
+		"entity":       entity,
+		"order":        types.Int64Value(int64(order)),
+		"renamed_from": types.StringNull(),
+	})
+	diags.Append(d...)
+	return propValue, !d.HasError()
+}
+
+func entitySchemaPropertyTypeFromJSONSchema(prop jsonSchemaProperty) (string, types.Object, error) {
+	noEntity := types.ObjectNull(entitySchemaEntityPropAttributeTypes)
+
+	if prop.Ref != "" {
+		if !entitySchemaTypeRegExp.MatchString(prop.Ref) {
+			return "", noEntity, fmt.Errorf("references %q, but `$ref` must reference a `terraform:` entity schema type", prop.Ref)
+		}
+		entity, d := types.ObjectValue(entitySchemaEntityPropAttributeTypes, map[string]attr.Value{
+			"type": types.StringValue(prop.Ref),
+		})
+		if d.HasError() {
+			return "", noEntity, fmt.Errorf("has an invalid `$ref`")
+		}
+		return "relation", entity, nil
+	}
+
+	jsonType, ok := jsonSchemaSingleType(prop.Type)
+	if !ok {
+		return "", noEntity, fmt.Errorf("must have a single `type` or a `$ref`, got: %v", prop.Type)
+	}
+
+	switch jsonType {
+	case "string":
+		if prop.Format == "date-time" {
+			return "date", noEntity, nil
+		}
+		return "text", noEntity, nil
+	case "number", "integer":
+		return "number", noEntity, nil
+	case "boolean":
+		return "boolean", noEntity, nil
+	default:
+		return "", noEntity, fmt.Errorf("has type %q, which can't be mapped to an entity schema property", jsonType)
+	}
+}
+
+// jsonSchemaSingleType returns the single type of a JSON Schema `type`
+// keyword, which is either a string or an array. A nullable type, e.g.
+// `["string", "null"]`, is the non-null type.
+func jsonSchemaSingleType(value interface{}) (string, bool) {
+	jsonType, ok := value.(string)
+	if jsonTypes, isList := value.([]interface{}); isList {
+		var nonNull []interface{}
+		for _, t := range jsonTypes {
+			if t != "null" {
+				nonNull = append(nonNull, t)
+			}
+		}
+		if len(nonNull) == 1 {
+			jsonType, ok = nonNull[0].(string)
+		}
+	}
+	return jsonType, ok
+}
+
+// decodeOrderedJSONObject decodes a JSON object, returning its keys in the
+// order they appear in the document along with their raw values. Duplicate keys
+// are an error, rather than the last value silently winning.
+func decodeOrderedJSONObject(data json.RawMessage) ([]string, map[string]json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	if tok != json.Delim('{') {
+		return nil, nil, fmt.Errorf("expected a JSON object")
+	}
+
+	var keys []string
+	values := make(map[string]json.RawMessage)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("expected a JSON object key")
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		if _, ok := values[key]; ok {
+			return nil, nil, fmt.Errorf("duplicate key %q", key)
+		}
+		keys = append(keys, key)
+		values[key] = value
+	}
+	return keys, values, nil
+}
+
+// jsonSchemaFromEntitySchema renders an entity schema as a JSON Schema
+// document, the reverse of `entitySchemaPropertiesFromJSONSchema`. The `$id` of
+// the document is the entity schema type, so that relation properties are
+// rendered as a `$ref` to the `$id` of the related entity schema.
+func jsonSchemaFromEntitySchema(entitySchema *gitbook.EntitySchema) (string, error) {
+	doc := jsonSchemaDocument{
+		Schema:     jsonSchemaDraft,
+		ID:         entitySchema.Type,
+		Title:      entitySchema.Title.Singular,
+		Type:       "object",
+		Properties: make(orderedJSONSchemaProperties, 0, len(entitySchema.Properties)),
+	}
+
+	for _, property := range entitySchema.Properties {
+		prop := jsonSchemaProperty{
+			Title:       property.Title,
+			Description: property.Description,
+			Deprecated:  property.Deprecated != nil && *property.Deprecated,
+		}
+
+		switch property.Type {
+		case "text":
+			prop.Type = "string"
+		case "date":
+			prop.Type = "string"
+			prop.Format = "date-time"
+		case "number", "boolean":
+			prop.Type = property.Type
+		case "relation":
+			prop.Ref, _ = property.Entity["type"].(string)
+		default:
+			return "", fmt.Errorf("property %q has type %q, which can't be rendered as JSON Schema", property.Name, property.Type)
+		}
+
+		doc.Properties = append(doc.Properties, namedJSONSchemaProperty{Name: property.Name, Property: prop})
+	}
+
+	rendered, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(rendered), nil
+}
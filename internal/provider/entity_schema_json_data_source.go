@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	gitbook "github.com/GitbookIO/go-gitbook/api"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func NewEntitySchemaJSONDataSource() datasource.DataSource {
+	return &entitySchemaJSONDataSource{}
+}
+
+// entitySchemaJSONDataSource defines the data source implementation.
+type entitySchemaJSONDataSource struct {
+	client *gitbook.OrganizationsApiService
+}
+
+type entitySchemaJSONDataSourceModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+	Type           types.String `tfsdk:"type"`
+	JSONSchema     types.String `tfsdk:"json_schema"`
+}
+
+func (d *entitySchemaJSONDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entity_schema_json"
+}
+
+func (d *entitySchemaJSONDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Renders an entity schema as a JSON Schema document.",
+
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the organization that owns the entity schema.",
+			},
+			"type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The type of the entity schema.",
+			},
+			"json_schema": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "The entity schema as a JSON Schema document, whose `$id` is the entity schema type. " +
+					"Properties are listed in the order they're displayed in GitBook, and relation properties " +
+					"are a `$ref` to the `$id` of the related entity schema.",
+			},
+		},
+	}
+}
+
+func (d *entitySchemaJSONDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*gitBookClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *gitBookClient, got: %T. Please report this issue to GitBook.", req.ProviderData),
+		)
+
+		return
+	}
+
+	client.addTokenError(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.client = client.OrganizationsApi
+}
+
+func (d *entitySchemaJSONDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model entitySchemaJSONDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := model.OrganizationID.ValueString()
+	entityType := model.Type.ValueString()
+
+	// Fetch the entity schema via the GitBook API.
+	entitySchema, _, err := d.client.GetEntitySchema(ctx, organizationID, entityType).Execute()
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error reading GitBook entity schema",
+			fmt.Sprintf("Could not fetch GitBook entity schema (organization: %q, type: %q)", organizationID, entityType),
+			err,
+		)
+		return
+	}
+
+	jsonSchema, err := jsonSchemaFromEntitySchema(entitySchema)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error rendering GitBook entity schema",
+			fmt.Sprintf("Could not render GitBook entity schema %q as JSON Schema: %s", entityType, err),
+		)
+		return
+	}
+	model.JSONSchema = types.StringValue(jsonSchema)
+
+	// Save data into Terraform state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
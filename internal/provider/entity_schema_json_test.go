@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// wantEntitySchemaProperty is the expected mapping of a JSON Schema property.
+type wantEntitySchemaProperty struct {
+	title       string
+	description string
+	propType    string
+	entity      string
+	order       int64
+}
+
+func TestEntitySchemaPropertiesFromJSONSchema(t *testing.T) {
+	tests := map[string]struct {
+		document     string
+		want         map[string]wantEntitySchemaProperty
+		wantError    bool
+		wantMessage  string
+		wantWarnings int
+	}{
+		"types": {
+			document: `{
+				"type": "object",
+				"properties": {
+					"name": {"type": "string", "title": "Name", "description": "The name"},
+					"created_at": {"type": "string", "format": "date-time"},
+					"size": {"type": "number"},
+					"count": {"type": "integer"},
+					"enabled": {"type": "boolean"},
+					"owner": {"$ref": "terraform:team", "title": "Owner"}
+				}
+			}`,
+			want: map[string]wantEntitySchemaProperty{
+				"name":       {title: "Name", description: "The name", propType: "text", order: 1},
+				"created_at": {title: "created_at", propType: "date", order: 2},
+				"size":       {title: "size", propType: "number", order: 3},
+				"count":      {title: "count", propType: "number", order: 4},
+				"enabled":    {title: "enabled", propType: "boolean", order: 5},
+				"owner":      {title: "Owner", propType: "relation", entity: "terraform:team", order: 6},
+			},
+		},
+		"nullable type": {
+			document: `{"properties": {"name": {"type": ["string", "null"]}}}`,
+			want: map[string]wantEntitySchemaProperty{
+				"name": {title: "name", propType: "text", order: 1},
+			},
+		},
+		"ignored keywords": {
+			document: `{"properties": {"name": {"type": "string", "minLength": 1, "default": "a"}}}`,
+			want: map[string]wantEntitySchemaProperty{
+				"name": {title: "name", propType: "text", order: 1},
+			},
+			wantWarnings: 1,
+		},
+		"root type as an array": {
+			document: `{"type": ["object"], "properties": {"name": {"type": "string"}}}`,
+			want: map[string]wantEntitySchemaProperty{
+				"name": {title: "name", propType: "text", order: 1},
+			},
+		},
+		"invalid JSON": {
+			document:  `{"properties":`,
+			wantError: true,
+		},
+		"not an object": {
+			document:  `{"type": "array", "items": {"type": "string"}}`,
+			wantError: true,
+		},
+		"root type array of another type": {
+			document:  `{"type": ["array"], "items": {"type": "string"}}`,
+			wantError: true,
+		},
+		"duplicate property": {
+			document:    `{"properties": {"name": {"type": "string"}, "size": {"type": "number"}, "name": {"type": "boolean"}}}`,
+			wantError:   true,
+			wantMessage: `duplicate key "name"`,
+		},
+		"missing properties": {
+			document:  `{"type": "object"}`,
+			wantError: true,
+		},
+		"no properties": {
+			document:  `{"type": "object", "properties": {}}`,
+			wantError: true,
+		},
+		"unsupported type": {
+			document:  `{"properties": {"tags": {"type": "array"}}}`,
+			wantError: true,
+		},
+		"multiple types": {
+			document:  `{"properties": {"value": {"type": ["string", "number"]}}}`,
+			wantError: true,
+		},
+		"structural keyword": {
+			document:  `{"properties": {"value": {"oneOf": [{"type": "string"}, {"type": "number"}]}}}`,
+			wantError: true,
+		},
+		"nested object": {
+			document:  `{"properties": {"address": {"type": "object", "properties": {"city": {"type": "string"}}}}}`,
+			wantError: true,
+		},
+		"reference to a non-terraform type": {
+			document:  `{"properties": {"owner": {"$ref": "#/definitions/team"}}}`,
+			wantError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			var diags diag.Diagnostics
+			props := entitySchemaPropertiesFromJSONSchema(test.document, &diags)
+
+			if test.wantError {
+				if !diags.HasError() {
+					t.Fatalf("expected an error, got none")
+				}
+				if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, test.wantMessage) {
+					t.Errorf("expected the error to mention %q, got %q", test.wantMessage, detail)
+				}
+				if !props.IsNull() {
+					t.Errorf("expected null properties on error, got %s", props)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if got := diags.WarningsCount(); got != test.wantWarnings {
+				t.Errorf("expected %d warnings, got %d: %v", test.wantWarnings, got, diags)
+			}
+
+			got := make(map[string]entitySchemaProperty)
+			if d := props.ElementsAs(ctx, &got, false); d.HasError() {
+				t.Fatalf("decoding properties: %v", d)
+			}
+			if len(got) != len(test.want) {
+				t.Errorf("expected %d properties, got %d", len(test.want), len(got))
+			}
+			for name, want := range test.want {
+				prop, ok := got[name]
+				if !ok {
+					t.Errorf("expected property %q", name)
+					continue
+				}
+				if prop.Title.ValueString() != want.title {
+					t.Errorf("property %q: expected title %q, got %q", name, want.title, prop.Title.ValueString())
+				}
+				if prop.Description.ValueString() != want.description {
+					t.Errorf("property %q: expected description %q, got %q", name, want.description, prop.Description.ValueString())
+				}
+				if prop.Type.ValueString() != want.propType {
+					t.Errorf("property %q: expected type %q, got %q", name, want.propType, prop.Type.ValueString())
+				}
+				if prop.Order.ValueInt64() != want.order {
+					t.Errorf("property %q: expected order %d, got %d", name, want.order, prop.Order.ValueInt64())
+				}
+				if !prop.RenamedFrom.IsNull() {
+					t.Errorf("property %q: expected renamed_from to be null, got %s", name, prop.RenamedFrom)
+				}
+
+				var entity entitySchemaPropertyEntity
+				if !prop.Entity.IsNull() {
+					if d := prop.Entity.As(ctx, &entity, basetypes.ObjectAsOptions{}); d.HasError() {
+						t.Fatalf("decoding entity: %v", d)
+					}
+				}
+				if entity.Type.ValueString() != want.entity {
+					t.Errorf("property %q: expected entity type %q, got %q", name, want.entity, entity.Type.ValueString())
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package provider
 
 import (
+	"sort"
+
 	gitbook "github.com/GitbookIO/go-gitbook/api"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -8,25 +10,31 @@ import (
 )
 
 type entitySchemaModel struct {
-	Type           types.String `tfsdk:"type"`
-	Title          types.Object `tfsdk:"title"`
-	Properties     types.Set    `tfsdk:"properties"`
-	OrganizationID types.String `tfsdk:"organization_id"`
+	Type                    types.String `tfsdk:"type"`
+	Title                   types.Object `tfsdk:"title"`
+	Properties              types.Map    `tfsdk:"properties"`
+	JSONSchema              types.String `tfsdk:"json_schema"`
+	OrganizationID          types.String `tfsdk:"organization_id"`
+	AllowDestructiveChanges types.Bool   `tfsdk:"allow_destructive_changes"`
+	ForceDestroy            types.Bool   `tfsdk:"force_destroy"`
+	AdoptExisting           types.Bool   `tfsdk:"adopt_existing"`
 }
 
-type entitySchemaProperties struct {
-	Name        types.String `tfsdk:"name"`
+type entitySchemaProperty struct {
 	Title       types.String `tfsdk:"title"`
 	Description types.String `tfsdk:"description"`
 	Type        types.String `tfsdk:"type"`
 	Entity      types.Object `tfsdk:"entity"`
+	Order       types.Int64  `tfsdk:"order"`
+	RenamedFrom types.String `tfsdk:"renamed_from"`
 }
 
-var entitySchemaPropertiesAttributeTypes = map[string]attr.Type{
-	"name":        types.StringType,
-	"title":       types.StringType,
-	"description": types.StringType,
-	"type":        types.StringType,
+var entitySchemaPropertyAttributeTypes = map[string]attr.Type{
+	"title":        types.StringType,
+	"description":  types.StringType,
+	"type":         types.StringType,
+	"order":        types.Int64Type,
+	"renamed_from": types.StringType,
 	"entity": types.ObjectType{
 		AttrTypes: entitySchemaEntityPropAttributeTypes,
 	},
@@ -51,9 +59,35 @@ var entitySchemaEntityPropAttributeTypes = map[string]attr.Type{
 }
 
 // parseEntitySchema merges an entity schema from GitBook into a Terraform model.
+//
+// The `order` of properties is only tracked for properties that had one in
+// the model. The known order values are reassigned following the order of the
+// properties returned by GitBook, so they're unchanged unless the properties
+// were reordered outside of Terraform.
 func (m *entitySchemaModel) parseEntitySchema(entitySchema *gitbook.EntitySchema, diags *diag.Diagnostics) {
 	m.Type = types.StringValue(entitySchema.Type)
 
+	var orders []int64
+	ordered := make(map[string]bool)
+	renamedFrom := make(map[string]attr.Value)
+	if !m.Properties.IsNull() && !m.Properties.IsUnknown() {
+		for name, value := range m.Properties.Elements() {
+			prop, ok := value.(types.Object)
+			if !ok {
+				continue
+			}
+			// `renamed_from` only exists in the configuration, so keep it as is.
+			if from, ok := prop.Attributes()["renamed_from"]; ok {
+				renamedFrom[name] = from
+			}
+			if order, ok := prop.Attributes()["order"].(types.Int64); ok && !order.IsNull() && !order.IsUnknown() {
+				orders = append(orders, order.ValueInt64())
+				ordered[name] = true
+			}
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool { return orders[i] < orders[j] })
+
 	title, d := types.ObjectValue(entitySchemaTitleAttributeTypes, map[string]attr.Value{
 		"singular": types.StringValue(entitySchema.Title.Singular),
 		"plural":   types.StringValue(entitySchema.Title.Plural),
@@ -64,14 +98,22 @@ func (m *entitySchemaModel) parseEntitySchema(entitySchema *gitbook.EntitySchema
 	}
 	m.Title = title
 
-	properties := make([]attr.Value, len(entitySchema.Properties))
+	properties := make(map[string]attr.Value, len(entitySchema.Properties))
 
-	for i, property := range entitySchema.Properties {
+	for _, property := range entitySchema.Properties {
 		modelPropAttributes := map[string]attr.Value{
-			"name":        types.StringValue(property.Name),
-			"title":       types.StringValue(property.Title),
-			"description": types.StringPointerValue(property.Description),
-			"type":        types.StringValue(property.Type),
+			"title":        types.StringValue(property.Title),
+			"description":  types.StringPointerValue(property.Description),
+			"type":         types.StringValue(property.Type),
+			"order":        types.Int64Null(),
+			"renamed_from": types.StringNull(),
+		}
+		if from, ok := renamedFrom[property.Name]; ok {
+			modelPropAttributes["renamed_from"] = from
+		}
+		if ordered[property.Name] {
+			modelPropAttributes["order"] = types.Int64Value(orders[0])
+			orders = orders[1:]
 		}
 		if property.Entity != nil {
 			entityType, _ := property.Entity["type"].(string)
@@ -86,24 +128,24 @@ func (m *entitySchemaModel) parseEntitySchema(entitySchema *gitbook.EntitySchema
 		} else {
 			modelPropAttributes["entity"] = types.ObjectNull(entitySchemaEntityPropAttributeTypes)
 		}
-		modelProp, d := types.ObjectValue(entitySchemaPropertiesAttributeTypes, modelPropAttributes)
+		modelProp, d := types.ObjectValue(entitySchemaPropertyAttributeTypes, modelPropAttributes)
 		if d.HasError() {
 			diags.Append(d...)
 			continue
 		}
-		properties[i] = modelProp
+		properties[property.Name] = modelProp
 	}
 	if diags.HasError() {
 		return
 	}
 
-	propsSetValue, d := types.SetValue(types.ObjectType{
-		AttrTypes: entitySchemaPropertiesAttributeTypes,
+	propsMapValue, d := types.MapValue(types.ObjectType{
+		AttrTypes: entitySchemaPropertyAttributeTypes,
 	}, properties)
 	if d.HasError() {
 		diags.Append(d...)
 		return
 	}
 
-	m.Properties = propsSetValue
+	m.Properties = propsMapValue
 }
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	gitbook "github.com/GitbookIO/go-gitbook/api"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// entitySchemaRename is a property renamed through its `renamed_from`
+// attribute.
+type entitySchemaRename struct {
+	From string
+	To   string
+}
+
+// entitySchemaRenames returns the properties of the planned entity schema
+// that are renamed from a property of the prior entity schema. Renames whose
+// prior property no longer exists have already been applied, and are ignored.
+func entitySchemaRenames(prior, planned map[string]entitySchemaProperty) []entitySchemaRename {
+	var renames []entitySchemaRename
+	for name, prop := range planned {
+		if prop.RenamedFrom.IsNull() || prop.RenamedFrom.IsUnknown() {
+			continue
+		}
+		from := prop.RenamedFrom.ValueString()
+		if _, ok := prior[from]; !ok {
+			continue
+		}
+		if _, ok := planned[from]; ok {
+			continue
+		}
+		renames = append(renames, entitySchemaRename{From: from, To: name})
+	}
+	sort.Slice(renames, func(i, j int) bool { return renames[i].To < renames[j].To })
+	return renames
+}
+
+// migrateRenamedProperties moves the values of renamed properties to their new
+// name on every entity of the entity schema.
+//
+// The entity schema is first set with both the renamed properties and their
+// prior definitions, so that no data is lost while entities are migrated. The
+// prior properties are dropped when the planned entity schema is set next.
+// Entities that were already migrated are skipped, so a failed migration can
+// be resumed by applying again.
+func (r *entitySchemaResource) migrateRenamedProperties(ctx context.Context, prior entitySchemaModel, planned gitbook.EntityRawSchema, renames []entitySchemaRename, diags *diag.Diagnostics) {
+	organizationID := prior.OrganizationID.ValueString()
+	entityType := prior.Type.ValueString()
+
+	priorRawSchema := entityRawSchemaFromModel(ctx, prior, diags)
+	if diags.HasError() {
+		return
+	}
+
+	// Keep the prior definition of renamed properties while migrating.
+	transitional := planned
+	transitional.Properties = append([]gitbook.EntityPropertySchema{}, planned.Properties...)
+	for _, rename := range renames {
+		for _, prop := range priorRawSchema.Properties {
+			if prop.Name == rename.From {
+				transitional.Properties = append(transitional.Properties, prop)
+			}
+		}
+	}
+
+	_, err := r.client.SetEntitySchema(ctx, organizationID, entityType).EntityRawSchema(transitional).Execute()
+	if err != nil {
+		addAPIError(
+			diags,
+			"Error updating GitBook entity schema",
+			"Could not add renamed properties to GitBook entity schema",
+			err,
+		)
+		return
+	}
+
+	_, err = waitForConsistency(ctx, func(ctx context.Context) (*gitbook.EntitySchema, error) {
+		entitySchema, _, err := r.client.GetEntitySchema(ctx, organizationID, entityType).Execute()
+		return entitySchema, err
+	}, func(entitySchema *gitbook.EntitySchema) bool {
+		return entityRawSchemaMatches(transitional, entitySchema)
+	})
+	if err != nil {
+		addAPIError(
+			diags,
+			"Error reading updated GitBook entity schema",
+			"Could not read GitBook entity schema with renamed properties",
+			err,
+		)
+		return
+	}
+
+	entities, err := listSchemaEntities(ctx, r.client, organizationID, entityType)
+	if err != nil {
+		addAPIError(
+			diags,
+			"Error listing GitBook entities",
+			fmt.Sprintf("Could not list entities of GitBook entity schema %q to migrate renamed properties", entityType),
+			err,
+		)
+		return
+	}
+
+	var upserts []gitbook.UpsertEntity
+	for _, entity := range entities {
+		if upsert, ok := renameEntityProperties(entity, renames); ok {
+			upserts = append(upserts, upsert)
+		}
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Migrating renamed properties of %d out of %d entities", len(upserts), len(entities)), map[string]interface{}{
+		"organization_id": organizationID,
+		"type":            entityType,
+	})
+
+	for start := 0; start < len(upserts); start += entityBulkChunkSize {
+		end := start + entityBulkChunkSize
+		if end > len(upserts) {
+			end = len(upserts)
+		}
+
+		opts := gitbook.UpsertSchemaEntitiesRequest{
+			Entities: upserts[start:end],
+		}
+		_, err := r.client.UpsertSchemaEntities(ctx, organizationID, entityType).UpsertSchemaEntitiesRequest(opts).Execute()
+		if err != nil {
+			addAPIError(
+				diags,
+				"Error migrating GitBook entities",
+				fmt.Sprintf("Could not migrate renamed properties of entities %d to %d of %d. Apply again to resume the migration", start+1, end, len(upserts)),
+				err,
+			)
+			return
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("Migrated renamed properties of %d out of %d entities", end, len(upserts)), map[string]interface{}{
+			"organization_id": organizationID,
+			"type":            entityType,
+		})
+	}
+}
+
+// renameEntityProperties returns the entity with the values of renamed
+// properties moved to their new name, and whether any value was moved.
+func renameEntityProperties(entity gitbook.Entity, renames []entitySchemaRename) (gitbook.UpsertEntity, bool) {
+	props := make(map[string]gitbook.UpsertEntityPropertiesValue, len(entity.Properties))
+	for name, value := range entity.Properties {
+		props[name] = value
+	}
+
+	renamed := false
+	for _, rename := range renames {
+		value, ok := props[rename.From]
+		if !ok {
+			continue
+		}
+		delete(props, rename.From)
+		// Don't overwrite a value that was set since the rename started.
+		if _, ok := props[rename.To]; !ok {
+			props[rename.To] = value
+		}
+		renamed = true
+	}
+
+	return gitbook.UpsertEntity{
+		EntityId:   entity.EntityId,
+		Properties: props,
+	}, renamed
+}
@@ -4,16 +4,20 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"sort"
+	"strings"
 
 	gitbook "github.com/GitbookIO/go-gitbook/api"
-	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var entitySchemaTypeRegExp = regexp.MustCompile("^terraform:")
@@ -33,6 +37,7 @@ func (r *entitySchemaResource) Metadata(ctx context.Context, req resource.Metada
 func (r *entitySchemaResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Entity schema resource",
+		Version:             entitySchemaResourceSchemaVersion,
 
 		Attributes: map[string]schema.Attribute{
 			"organization_id": schema.StringAttribute{
@@ -58,16 +63,14 @@ func (r *entitySchemaResource) Schema(ctx context.Context, req resource.SchemaRe
 					},
 				},
 			},
-			"properties": schema.SetNestedAttribute{
-				Required: true,
-				MarkdownDescription: "The properties of the entity schema. Each property must have a unique name. " +
-					"At least one property is required.",
+			"properties": schema.MapNestedAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "The properties of the entity schema, where each key is the name of the property. " +
+					"At least one property is required. Exactly one of `properties` or `json_schema` must be set, " +
+					"and `properties` is computed from `json_schema` when it's set.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"name": schema.StringAttribute{
-							Required:            true,
-							MarkdownDescription: "The name of the property. Must be unique within the entity schema.",
-						},
 						"title": schema.StringAttribute{
 							Required:            true,
 							MarkdownDescription: "The title of the property.",
@@ -83,22 +86,57 @@ func (r *entitySchemaResource) Schema(ctx context.Context, req resource.SchemaRe
 								stringvalidator.OneOf("text", "number", "boolean", "date", "relation"),
 							},
 						},
+						"renamed_from": schema.StringAttribute{
+							Optional: true,
+							MarkdownDescription: "The prior name of the property, when renaming it. " +
+								"On update, the value of the prior property is moved to this property on every existing entity, " +
+								"before the prior property is removed. A failed migration is resumed by applying again.",
+						},
+						"order": schema.Int64Attribute{
+							Optional: true,
+							MarkdownDescription: "The position of the property when displayed in GitBook, in ascending order. " +
+								"Properties without an order are displayed last, sorted by name.",
+						},
 						"entity": schema.SingleNestedAttribute{
 							Optional:            true,
-							MarkdownDescription: "Required when type is `relation`.",
+							MarkdownDescription: "The entity schema the property relates to. Required when type is `relation`, not allowed otherwise.",
 							Attributes: map[string]schema.Attribute{
 								"type": schema.StringAttribute{
 									Required:            true,
-									MarkdownDescription: "The type of the entity schema that can be used for relations.",
+									MarkdownDescription: "The type of the entity schema that can be used for relations. Must be prefixed with `terraform:`.",
 								},
 							},
 						},
 					},
 				},
-				Validators: []validator.Set{
-					setvalidator.SizeAtLeast(1),
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					mapvalidator.ExactlyOneOf(path.MatchRoot("json_schema")),
 				},
 			},
+			"json_schema": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "A JSON Schema document describing the properties of the entity schema, as an alternative to `properties`. " +
+					"Each property of the document maps to an entity schema property, in the order they appear: " +
+					"`string` maps to `text`, or `date` with `format: date-time`, `number` and `integer` map to `number`, " +
+					"`boolean` maps to `boolean`, and a `$ref` to a `terraform:` entity schema type maps to `relation`. " +
+					"Other constructs, such as nested objects, arrays, or composition keywords, aren't supported.",
+			},
+			"allow_destructive_changes": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Allow updates that remove a property or change the type of a property. " +
+					"Such changes invalidate the data of that property on every existing entity of the entity schema.",
+			},
+			"force_destroy": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Delete every entity of the entity schema before deleting the entity schema itself. " +
+					"The value must be applied to the state before destroying the resource for it to take effect.",
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Allow the creation of the resource to take over an entity schema that already exists in GitBook. " +
+					"By default, creating an entity schema that already exists fails, and it must be imported instead.",
+			},
 		},
 	}
 }
@@ -109,17 +147,22 @@ func (r *entitySchemaResource) Configure(ctx context.Context, req resource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*gitbook.APIClient)
+	client, ok := req.ProviderData.(*gitBookClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *gitbook.APIClient, got: %T. Please report this issue to GitBook.", req.ProviderData),
+			fmt.Sprintf("Expected *gitBookClient, got: %T. Please report this issue to GitBook.", req.ProviderData),
 		)
 
 		return
 	}
 
+	client.addTokenError(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	r.client = client.OrganizationsApi
 }
 
@@ -132,18 +175,79 @@ func (r *entitySchemaResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	// The properties are unknown at plan time when `json_schema` was.
+	if model.Properties.IsUnknown() {
+		model.Properties = entitySchemaPropertiesFromJSONSchema(model.JSONSchema.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	entityRawSchema := entityRawSchemaFromModel(ctx, *model, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	organizationID := model.OrganizationID.ValueString()
+	entityType := model.Type.ValueString()
+
+	// Refuse to overwrite an entity schema that isn't managed by this resource,
+	// unless explicitly adopting it.
+	_, _, err := r.client.GetEntitySchema(ctx, organizationID, entityType).Execute()
+	switch {
+	case err == nil && !model.AdoptExisting.ValueBool():
+		addEntitySchemaExistsError(&resp.Diagnostics, organizationID, entityType)
+		return
+	case err == nil:
+		tflog.Info(ctx, "Adopting existing GitBook entity schema", map[string]interface{}{
+			"organization_id": organizationID,
+			"type":            entityType,
+		})
+	case !isNotFoundError(err):
+		addAPIError(
+			&resp.Diagnostics,
+			"Error reading GitBook entity schema",
+			fmt.Sprintf("Could not check whether GitBook entity schema %q already exists", entityType),
+			err,
+		)
+		return
+	}
+
 	// Create entity schema via the GitBook API.
-	_, err := r.client.SetEntitySchema(ctx, model.OrganizationID.ValueString(), model.Type.ValueString()).EntityRawSchema(*entityRawSchema).Execute()
+	_, err = r.client.SetEntitySchema(ctx, organizationID, entityType).EntityRawSchema(*entityRawSchema).Execute()
 	if err != nil {
-		errMessage := parseErrorMessage(err)
-		resp.Diagnostics.AddError(
+		addPropertiesAPIError(
+			&resp.Diagnostics,
+			model.Properties,
 			"Error creating GitBook entity schema",
-			fmt.Sprintf("Could not create GitBook entity schema: %v", errMessage),
+			"Could not create GitBook entity schema",
+			err,
+		)
+		return
+	}
+
+	// Save the identifying attributes right away, so that the entity schema is
+	// tracked even if reading it back fails.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), organizationID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), entityType)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Wait for GitBook to return the written entity schema, as reads can lag
+	// behind writes.
+	_, err = waitForConsistency(ctx, func(ctx context.Context) (*gitbook.EntitySchema, error) {
+		entitySchema, _, err := r.client.GetEntitySchema(ctx, organizationID, entityType).Execute()
+		return entitySchema, err
+	}, func(entitySchema *gitbook.EntitySchema) bool {
+		return entityRawSchemaMatches(*entityRawSchema, entitySchema)
+	})
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error reading created GitBook entity schema",
+			"Could not read created GitBook entity schema",
+			err,
 		)
 		return
 	}
@@ -165,11 +269,18 @@ func (r *entitySchemaResource) Read(ctx context.Context, req resource.ReadReques
 
 	// Fetch the entitySchema via the GitBook API.
 	entitySchema, _, err := r.client.GetEntitySchema(ctx, organizationID, entityType).Execute()
+	if isNotFoundError(err) {
+		// The entity schema was deleted outside of Terraform, so remove it from state
+		// to have it recreated.
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		errMessage := parseErrorMessage(err)
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Error reading GitBook entity schema",
-			fmt.Sprintf("Could not fetch GitBook entity schema (organization: %q, type: %q): %v", organizationID, entityType, errMessage),
+			fmt.Sprintf("Could not fetch GitBook entity schema (organization: %q, type: %q)", organizationID, entityType),
+			err,
 		)
 		return
 	}
@@ -192,18 +303,88 @@ func (r *entitySchemaResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	var state entitySchemaModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The properties are unknown at plan time when `json_schema` was, so
+	// destructive changes can only be guarded against now.
+	if model.Properties.IsUnknown() {
+		model.Properties = entitySchemaPropertiesFromJSONSchema(model.JSONSchema.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		changes := destructiveEntitySchemaChanges(ctx, state, *model, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if len(changes) > 0 {
+			if !model.AllowDestructiveChanges.ValueBool() {
+				r.addDestructiveChangesError(ctx, &resp.Diagnostics, state, changes)
+				return
+			}
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("properties"),
+				"Destructive GitBook entity schema change",
+				r.destructiveChangesDetail(ctx, state, changes),
+			)
+		}
+	}
+
 	entityRawSchema := entityRawSchemaFromModel(ctx, *model, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	organizationID := model.OrganizationID.ValueString()
+	entityType := model.Type.ValueString()
+
+	// Move the values of renamed properties to their new name first, so that
+	// they're not lost when the prior properties are dropped.
+	priorProps := make(map[string]entitySchemaProperty, len(state.Properties.Elements()))
+	resp.Diagnostics.Append(state.Properties.ElementsAs(ctx, &priorProps, false)...)
+	plannedProps := make(map[string]entitySchemaProperty, len(model.Properties.Elements()))
+	resp.Diagnostics.Append(model.Properties.ElementsAs(ctx, &plannedProps, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if renames := entitySchemaRenames(priorProps, plannedProps); len(renames) > 0 {
+		r.migrateRenamedProperties(ctx, state, *entityRawSchema, renames, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Update entity schema via the GitBook API.
-	_, err := r.client.SetEntitySchema(ctx, model.OrganizationID.ValueString(), model.Type.ValueString()).EntityRawSchema(*entityRawSchema).Execute()
+	_, err := r.client.SetEntitySchema(ctx, organizationID, entityType).EntityRawSchema(*entityRawSchema).Execute()
 	if err != nil {
-		errMessage := parseErrorMessage(err)
-		resp.Diagnostics.AddError(
+		addPropertiesAPIError(
+			&resp.Diagnostics,
+			model.Properties,
 			"Error updating GitBook entity schema",
-			fmt.Sprintf("Could not update GitBook entity schema: %v", errMessage),
+			"Could not update GitBook entity schema",
+			err,
+		)
+		return
+	}
+
+	// Wait for GitBook to return the written entity schema, as reads can lag
+	// behind writes.
+	_, err = waitForConsistency(ctx, func(ctx context.Context) (*gitbook.EntitySchema, error) {
+		entitySchema, _, err := r.client.GetEntitySchema(ctx, organizationID, entityType).Execute()
+		return entitySchema, err
+	}, func(entitySchema *gitbook.EntitySchema) bool {
+		return entityRawSchemaMatches(*entityRawSchema, entitySchema)
+	})
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error reading updated GitBook entity schema",
+			"Could not read updated GitBook entity schema",
+			err,
 		)
 		return
 	}
@@ -224,31 +405,263 @@ func (r *entitySchemaResource) Delete(ctx context.Context, req resource.DeleteRe
 	organizationID := model.OrganizationID.ValueString()
 	entityType := model.Type.ValueString()
 
+	// Delete all entities of the entity schema first, if requested.
+	if model.ForceDestroy.ValueBool() {
+		entities, err := listSchemaEntities(ctx, r.client, organizationID, entityType)
+		if err != nil {
+			addAPIError(
+				&resp.Diagnostics,
+				"Error listing GitBook entities",
+				fmt.Sprintf("Could not list entities of GitBook entity schema %q to force destroy it", entityType),
+				err,
+			)
+			return
+		}
+
+		entityIDs := make([]string, len(entities))
+		for i, entity := range entities {
+			entityIDs[i] = entity.EntityId
+		}
+
+		err = deleteSchemaEntities(ctx, r.client, organizationID, entityType, entityIDs)
+		if err != nil {
+			addAPIError(
+				&resp.Diagnostics,
+				"Error deleting GitBook entities",
+				fmt.Sprintf("Could not delete entities of GitBook entity schema %q to force destroy it", entityType),
+				err,
+			)
+			return
+		}
+	}
+
 	_, err := r.client.DeleteEntitySchema(ctx, organizationID, entityType).Execute()
 	if err != nil {
-		errMessage := parseErrorMessage(err)
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Error deleting GitBook entity schema",
-			fmt.Sprintf("Could not delete GitBook entity schema: %v", errMessage),
+			"Could not delete GitBook entity schema",
+			err,
 		)
 	}
 }
 
+// ImportState imports an entity schema using an ID of the form
+// `<organization_id>/<type>`.
 func (r *entitySchemaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	organizationID, entityType, ok := strings.Cut(req.ID, "/")
+	if !ok || organizationID == "" || entityType == "" {
+		resp.Diagnostics.AddError(
+			"Invalid GitBook entity schema import ID",
+			fmt.Sprintf("Expected an import ID of the form `<organization_id>/<type>`, got: %q.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), organizationID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), entityType)...)
+}
+
+func (r *entitySchemaResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// Nothing to check when destroying the entity schema.
+		return
+	}
+
+	r.modifyJSONSchemaPlan(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case req.State.Raw.IsNull():
+		r.modifyCreatePlan(ctx, req, resp)
+	default:
+		r.modifyUpdatePlan(ctx, req, resp)
+	}
+}
+
+// modifyJSONSchemaPlan plans the properties mapped from `json_schema`, so that
+// changes to the document are shown as changes to the properties.
+func (r *entitySchemaResource) modifyJSONSchemaPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var jsonSchema types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("json_schema"), &jsonSchema)...)
+	if resp.Diagnostics.HasError() || jsonSchema.IsNull() {
+		return
+	}
+
+	properties := types.MapUnknown(types.ObjectType{AttrTypes: entitySchemaPropertyAttributeTypes})
+	if !jsonSchema.IsUnknown() {
+		properties = entitySchemaPropertiesFromJSONSchema(jsonSchema.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("properties"), properties)...)
+}
+
+// modifyCreatePlan reports, at plan time, entity schemas that already exist in
+// GitBook and would be overwritten by the creation of the resource.
+func (r *entitySchemaResource) modifyCreatePlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan entitySchemaModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || r.client == nil || plan.OrganizationID.IsUnknown() || plan.Type.IsUnknown() {
+		return
+	}
+
+	organizationID := plan.OrganizationID.ValueString()
+	entityType := plan.Type.ValueString()
+
+	// Errors are left for `Create` to report.
+	_, _, err := r.client.GetEntitySchema(ctx, organizationID, entityType).Execute()
+	if err != nil {
+		return
+	}
+
+	if !plan.AdoptExisting.ValueBool() {
+		addEntitySchemaExistsError(&resp.Diagnostics, organizationID, entityType)
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("adopt_existing"),
+		"Existing GitBook entity schema will be adopted",
+		fmt.Sprintf("The GitBook entity schema %q already exists in organization %q. "+
+			"Applying this plan will take it over and overwrite its definition.", entityType, organizationID),
+	)
+}
+
+// modifyUpdatePlan guards against updates that would invalidate the data of
+// existing entities, i.e. removing a property or changing the type of a
+// property.
+func (r *entitySchemaResource) modifyUpdatePlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var state, plan entitySchemaModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Properties.IsUnknown() {
+		return
+	}
+
+	changes := destructiveEntitySchemaChanges(ctx, state, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() || len(changes) == 0 {
+		return
+	}
+
+	if !plan.AllowDestructiveChanges.ValueBool() {
+		r.addDestructiveChangesError(ctx, &resp.Diagnostics, state, changes)
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("properties"),
+		"Destructive GitBook entity schema change",
+		r.destructiveChangesDetail(ctx, state, changes),
+	)
+}
+
+// addDestructiveChangesError reports destructive changes to an entity schema
+// that aren't allowed.
+func (r *entitySchemaResource) addDestructiveChangesError(ctx context.Context, diags *diag.Diagnostics, state entitySchemaModel, changes []string) {
+	diags.AddAttributeError(
+		path.Root("properties"),
+		"Destructive GitBook entity schema change",
+		r.destructiveChangesDetail(ctx, state, changes)+"\n\nSet `allow_destructive_changes = true` to apply these changes anyway.",
+	)
+}
+
+// destructiveChangesDetail describes destructive changes to an entity schema,
+// with the number of existing entities they affect when it can be read.
+func (r *entitySchemaResource) destructiveChangesDetail(ctx context.Context, state entitySchemaModel, changes []string) string {
+	affected := "an unknown number of"
+	if r.client != nil {
+		entitySchema, _, err := r.client.GetEntitySchema(ctx, state.OrganizationID.ValueString(), state.Type.ValueString()).Execute()
+		if err == nil {
+			affected = fmt.Sprintf("%d", int(entitySchema.Entities))
+		}
+	}
+
+	return fmt.Sprintf("The following changes to entity schema %q invalidate the data of %s existing entities:\n\n- %s",
+		state.Type.ValueString(), affected, strings.Join(changes, "\n- "))
+}
+
+// destructiveEntitySchemaChanges describes the properties that are removed, or
+// whose type changes, between two versions of an entity schema.
+func destructiveEntitySchemaChanges(ctx context.Context, prior, planned entitySchemaModel, diags *diag.Diagnostics) []string {
+	priorProps := make(map[string]entitySchemaProperty, len(prior.Properties.Elements()))
+	diags.Append(prior.Properties.ElementsAs(ctx, &priorProps, false)...)
+	plannedProps := make(map[string]entitySchemaProperty, len(planned.Properties.Elements()))
+	diags.Append(planned.Properties.ElementsAs(ctx, &plannedProps, false)...)
+	if diags.HasError() {
+		return nil
+	}
+
+	// The values of renamed properties are migrated, so a rename is only
+	// destructive if it also changes the type of the property.
+	renamedTo := make(map[string]string)
+	for _, rename := range entitySchemaRenames(priorProps, plannedProps) {
+		renamedTo[rename.From] = rename.To
+	}
+
+	var changes []string
+	for name, prop := range priorProps {
+		plannedProp, ok := plannedProps[name]
+		if to, renamed := renamedTo[name]; renamed {
+			plannedProp, ok = plannedProps[to], true
+		}
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("property %q is removed", name))
+		case plannedProp.Type.IsUnknown():
+			continue
+		case !plannedProp.Type.Equal(prop.Type):
+			changes = append(changes, fmt.Sprintf("property %q changes type from %q to %q", name, prop.Type.ValueString(), plannedProp.Type.ValueString()))
+		}
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+func addEntitySchemaExistsError(diags *diag.Diagnostics, organizationID, entityType string) {
+	diags.AddAttributeError(
+		path.Root("type"),
+		"GitBook entity schema already exists",
+		fmt.Sprintf("The GitBook entity schema %q already exists in organization %q, and may be managed elsewhere. ", entityType, organizationID)+
+			fmt.Sprintf("Import it with the ID `%s/%s` to manage it with this resource, ", organizationID, entityType)+
+			"or set `adopt_existing = true` to deliberately take it over.",
+	)
 }
 
 func entityRawSchemaFromModel(ctx context.Context, model entitySchemaModel, diags *diag.Diagnostics) *gitbook.EntityRawSchema {
-	propsState := make([]entitySchemaProperties, 0, len(model.Properties.Elements()))
+	propsState := make(map[string]entitySchemaProperty, len(model.Properties.Elements()))
 	diags.Append(model.Properties.ElementsAs(ctx, &propsState, false)...)
 	if diags.HasError() {
 		return nil
 	}
 
-	props := make([]gitbook.EntityPropertySchema, len(model.Properties.Elements()))
-	for i, prop := range propsState {
+	// Sort the properties by `order`, followed by properties without an order,
+	// and then by name, so that they're sent in a stable order.
+	names := make([]string, 0, len(propsState))
+	for name := range propsState {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := propsState[names[i]].Order, propsState[names[j]].Order
+		switch {
+		case a.IsNull() != b.IsNull():
+			return b.IsNull()
+		case a.ValueInt64() != b.ValueInt64():
+			return a.ValueInt64() < b.ValueInt64()
+		default:
+			return names[i] < names[j]
+		}
+	})
+
+	props := make([]gitbook.EntityPropertySchema, len(names))
+	for i, name := range names {
+		prop := propsState[name]
 		props[i] = gitbook.EntityPropertySchema{
-			Name:        prop.Name.ValueString(),
+			Name:        name,
 			Title:       prop.Title.ValueString(),
 			Description: prop.Description.ValueStringPointer(),
 			Type:        prop.Type.ValueString(),
@@ -280,3 +693,47 @@ func entityRawSchemaFromModel(ctx context.Context, model entitySchemaModel, diag
 		Properties: props,
 	}
 }
+
+// entityRawSchemaMatches reports whether an entity schema read from GitBook
+// matches the raw entity schema that was written.
+func entityRawSchemaMatches(rawSchema gitbook.EntityRawSchema, entitySchema *gitbook.EntitySchema) bool {
+	if entitySchema == nil || entitySchema.Title != rawSchema.Title {
+		return false
+	}
+
+	props := make(map[string]gitbook.EntityPropertySchema, len(entitySchema.Properties))
+	positions := make(map[string]int, len(entitySchema.Properties))
+	for i, prop := range entitySchema.Properties {
+		props[prop.Name] = prop
+		positions[prop.Name] = i
+	}
+	position := -1
+	for _, expected := range rawSchema.Properties {
+		prop, ok := props[expected.Name]
+		delete(props, expected.Name)
+
+		// Properties must be returned in the order they were written.
+		if !ok || positions[expected.Name] < position {
+			return false
+		}
+		position = positions[expected.Name]
+
+		if !ok || prop.Title != expected.Title || prop.Type != expected.Type {
+			return false
+		}
+		if prop.GetDescription() != expected.GetDescription() {
+			return false
+		}
+		if expected.Entity != nil && prop.Entity["type"] != expected.Entity["type"] {
+			return false
+		}
+	}
+
+	// Properties that were removed may still be returned as deprecated.
+	for _, prop := range props {
+		if !prop.GetDeprecated() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// fakeEntitySchemaAPI serves a single entity schema of the GitBook API.
+type fakeEntitySchemaAPI struct {
+	mu sync.Mutex
+	// schema is the JSON of the entity schema, nil when it doesn't exist.
+	schema []byte
+	// writes counts the writes of the entity schema.
+	writes int
+	// readStatus, if set, is the status of reads once the entity schema was
+	// written.
+	readStatus int
+}
+
+func (api *fakeEntitySchemaAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodGet && api.readStatus != 0 && api.writes > 0:
+		w.WriteHeader(api.readStatus)
+		_, _ = fmt.Fprintf(w, `{"error":{"code":%d,"message":%q}}`, api.readStatus, http.StatusText(api.readStatus))
+	case r.Method == http.MethodGet && api.schema == nil:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"error":{"code":404,"message":"Not found"}}`)
+	case r.Method == http.MethodGet:
+		_, _ = w.Write(api.schema)
+	case r.Method == http.MethodPut:
+		api.schema, _ = io.ReadAll(r.Body)
+		api.writes++
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// newTestEntitySchemaModel returns the model of an entity schema defined by a
+// JSON Schema document.
+func newTestEntitySchemaModel(t *testing.T, jsonSchema string) entitySchemaModel {
+	t.Helper()
+	var diags diag.Diagnostics
+	properties := entitySchemaPropertiesFromJSONSchema(jsonSchema, &diags)
+	if diags.HasError() {
+		t.Fatalf("mapping JSON Schema: %v", diags)
+	}
+	return entitySchemaModel{
+		OrganizationID: types.StringValue("org"),
+		Type:           types.StringValue("terraform:service"),
+		Title: types.ObjectValueMust(entitySchemaTitleAttributeTypes, map[string]attr.Value{
+			"singular": types.StringValue("Service"),
+			"plural":   types.StringValue("Services"),
+		}),
+		Properties:              properties,
+		JSONSchema:              types.StringValue(jsonSchema),
+		AllowDestructiveChanges: types.BoolNull(),
+		ForceDestroy:            types.BoolNull(),
+		AdoptExisting:           types.BoolNull(),
+	}
+}
+
+func TestEntitySchemaResourceUpdateUnknownJSONSchema(t *testing.T) {
+	const priorJSONSchema = `{"properties": {"name": {"type": "string"}, "size": {"type": "number"}}}`
+
+	tests := map[string]struct {
+		jsonSchema              string
+		allowDestructiveChanges bool
+		wantError               bool
+		wantWarning             bool
+	}{
+		"removed property": {
+			jsonSchema: `{"properties": {"name": {"type": "string"}}}`,
+			wantError:  true,
+		},
+		"changed property type": {
+			jsonSchema: `{"properties": {"name": {"type": "string"}, "size": {"type": "string"}}}`,
+			wantError:  true,
+		},
+		"allowed destructive change": {
+			jsonSchema:              `{"properties": {"name": {"type": "string"}}}`,
+			allowDestructiveChanges: true,
+			wantWarning:             true,
+		},
+		"added property": {
+			jsonSchema: `{"properties": {"name": {"type": "string"}, "size": {"type": "number"}, "owner": {"type": "string"}}}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			api := &fakeEntitySchemaAPI{
+				schema: []byte(`{"type":"terraform:service","title":{"singular":"Service","plural":"Services"},"entities":3,"properties":[]}`),
+			}
+			r := &entitySchemaResource{client: newTestAPIClient(t, api).OrganizationsApi}
+
+			prior := newTestEntitySchemaModel(t, priorJSONSchema)
+			// The properties are unknown when planned from an unknown `json_schema`.
+			planned := newTestEntitySchemaModel(t, test.jsonSchema)
+			planned.Properties = types.MapUnknown(types.ObjectType{AttrTypes: entitySchemaPropertyAttributeTypes})
+			planned.AllowDestructiveChanges = types.BoolValue(test.allowDestructiveChanges)
+
+			state := newTestResourceState(t, r, prior)
+			req := resource.UpdateRequest{
+				Plan:  newTestResourcePlan(t, r, planned),
+				State: state,
+			}
+			resp := resource.UpdateResponse{State: state}
+			r.Update(ctx, req, &resp)
+
+			if !test.wantError {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected error: %v", resp.Diagnostics)
+				}
+				if api.writes != 1 {
+					t.Errorf("expected the entity schema to be written, got %d writes", api.writes)
+				}
+				if got := resp.Diagnostics.WarningsCount() > 0; got != test.wantWarning {
+					t.Errorf("expected warning %t, got %v", test.wantWarning, resp.Diagnostics)
+				}
+				return
+			}
+
+			if !resp.Diagnostics.HasError() {
+				t.Fatalf("expected a destructive change error")
+			}
+			if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, `"size"`) || !strings.Contains(detail, "3 existing entities") {
+				t.Errorf("expected the error to describe the change to %q and its 3 entities, got %q", "size", detail)
+			}
+			if api.writes != 0 {
+				t.Errorf("expected the entity schema not to be written, got %d writes", api.writes)
+			}
+		})
+	}
+}
+
+func TestEntitySchemaResourceCreateTracksWrittenSchema(t *testing.T) {
+	ctx := context.Background()
+	// Reading the written entity schema back fails, after it was created.
+	api := &fakeEntitySchemaAPI{readStatus: http.StatusForbidden}
+	r := &entitySchemaResource{client: newTestAPIClient(t, api).OrganizationsApi}
+
+	planned := newTestEntitySchemaModel(t, `{"properties": {"name": {"type": "string"}}}`)
+	req := resource.CreateRequest{Plan: newTestResourcePlan(t, r, planned)}
+	resp := resource.CreateResponse{State: newTestNullResourceState(r)}
+	r.Create(ctx, req, &resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatalf("expected an error reading the created entity schema")
+	}
+	if api.writes != 1 {
+		t.Fatalf("expected the entity schema to be written, got %d writes", api.writes)
+	}
+
+	// The entity schema must be tracked, for Terraform to mark it as tainted.
+	var organizationID, entityType types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("organization_id"), &organizationID)...)
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("type"), &entityType)...)
+	if !organizationID.Equal(types.StringValue("org")) || !entityType.Equal(types.StringValue("terraform:service")) {
+		t.Errorf("expected the state to track entity schema %q of %q, got %s of %s", "terraform:service", "org", entityType, organizationID)
+	}
+}
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// entitySchemaResourceSchemaVersion is the current version of the
+// `gitbook_entity_schema` resource schema. It must be incremented, and a state
+// upgrader added, for every change to the shape of the resource state.
+const entitySchemaResourceSchemaVersion = 1
+
+// entitySchemaModelV0 is the state model of version 0 of the
+// `gitbook_entity_schema` resource schema, where `properties` was a set of
+// objects with a `name` attribute.
+type entitySchemaModelV0 struct {
+	Type                    types.String `tfsdk:"type"`
+	Title                   types.Object `tfsdk:"title"`
+	Properties              types.Set    `tfsdk:"properties"`
+	OrganizationID          types.String `tfsdk:"organization_id"`
+	AllowDestructiveChanges types.Bool   `tfsdk:"allow_destructive_changes"`
+}
+
+type entitySchemaPropertyV0 struct {
+	Name        types.String `tfsdk:"name"`
+	Title       types.String `tfsdk:"title"`
+	Description types.String `tfsdk:"description"`
+	Type        types.String `tfsdk:"type"`
+	Entity      types.Object `tfsdk:"entity"`
+}
+
+func (r *entitySchemaResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   entitySchemaResourceSchemaV0(),
+			StateUpgrader: upgradeEntitySchemaStateV0,
+		},
+	}
+}
+
+// upgradeEntitySchemaStateV0 upgrades state from version 0 to version 1, which
+// changed `properties` from a set to a map keyed by property name.
+func upgradeEntitySchemaStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior entitySchemaModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	priorProps := make([]entitySchemaPropertyV0, 0, len(prior.Properties.Elements()))
+	resp.Diagnostics.Append(prior.Properties.ElementsAs(ctx, &priorProps, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	props := make(map[string]attr.Value, len(priorProps))
+	for _, prop := range priorProps {
+		propValue, d := types.ObjectValue(entitySchemaPropertyAttributeTypes, map[string]attr.Value{
+			"title":        prop.Title,
+			"description":  prop.Description,
+			"type":         prop.Type,
+			"entity":       prop.Entity,
+			"order":        types.Int64Null(),
+			"renamed_from": types.StringNull(),
+		})
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		props[prop.Name.ValueString()] = propValue
+	}
+
+	propsValue, d := types.MapValue(types.ObjectType{AttrTypes: entitySchemaPropertyAttributeTypes}, props)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upgraded := entitySchemaModel{
+		Type:                    prior.Type,
+		Title:                   prior.Title,
+		Properties:              propsValue,
+		OrganizationID:          prior.OrganizationID,
+		AllowDestructiveChanges: prior.AllowDestructiveChanges,
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
+
+func entitySchemaResourceSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				Required: true,
+			},
+			"type": schema.StringAttribute{
+				Required: true,
+			},
+			"title": schema.SingleNestedAttribute{
+				Required: true,
+				Attributes: map[string]schema.Attribute{
+					"singular": schema.StringAttribute{
+						Required: true,
+					},
+					"plural": schema.StringAttribute{
+						Required: true,
+					},
+				},
+			},
+			"properties": schema.SetNestedAttribute{
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required: true,
+						},
+						"title": schema.StringAttribute{
+							Required: true,
+						},
+						"description": schema.StringAttribute{
+							Optional: true,
+						},
+						"type": schema.StringAttribute{
+							Required: true,
+						},
+						"entity": schema.SingleNestedAttribute{
+							Optional: true,
+							Attributes: map[string]schema.Attribute{
+								"type": schema.StringAttribute{
+									Required: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			"allow_destructive_changes": schema.BoolAttribute{
+				Optional: true,
+			},
+		},
+	}
+}
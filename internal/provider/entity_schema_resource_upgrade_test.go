@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestUpgradeEntitySchemaStateV0(t *testing.T) {
+	tests := map[string]struct {
+		fixture                     string
+		wantProperties              []string
+		wantAllowDestructiveChanges types.Bool
+	}{
+		// State written by the provider before `allow_destructive_changes`
+		// existed, which has no such key.
+		"baseline": {
+			fixture:                     "testdata/entity_schema_state_v0.json",
+			wantProperties:              []string{"name", "owner"},
+			wantAllowDestructiveChanges: types.BoolNull(),
+		},
+		"allow destructive changes": {
+			fixture:                     "testdata/entity_schema_state_v0_destructive.json",
+			wantProperties:              []string{"name"},
+			wantAllowDestructiveChanges: types.BoolValue(true),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			state := upgradeStateFixture(t, &entitySchemaResource{}, 0, test.fixture)
+
+			var model entitySchemaModel
+			if diags := state.Get(ctx, &model); diags.HasError() {
+				t.Fatalf("decoding upgraded state: %v", diags)
+			}
+
+			if got := model.Type.ValueString(); got != "terraform:example" {
+				t.Errorf("expected type %q, got %q", "terraform:example", got)
+			}
+			if !model.AllowDestructiveChanges.Equal(test.wantAllowDestructiveChanges) {
+				t.Errorf("expected allow_destructive_changes %s, got %s", test.wantAllowDestructiveChanges, model.AllowDestructiveChanges)
+			}
+
+			props := make(map[string]entitySchemaProperty)
+			if diags := model.Properties.ElementsAs(ctx, &props, false); diags.HasError() {
+				t.Fatalf("decoding properties: %v", diags)
+			}
+			if len(props) != len(test.wantProperties) {
+				t.Errorf("expected %d properties, got %d", len(test.wantProperties), len(props))
+			}
+			for _, name := range test.wantProperties {
+				prop, ok := props[name]
+				if !ok {
+					t.Errorf("expected property %q in the map", name)
+					continue
+				}
+				if !prop.Order.IsNull() {
+					t.Errorf("expected order of property %q to be null, got %s", name, prop.Order)
+				}
+				if !prop.RenamedFrom.IsNull() {
+					t.Errorf("expected renamed_from of property %q to be null, got %s", name, prop.RenamedFrom)
+				}
+			}
+
+			if owner, ok := props["owner"]; ok {
+				if got := owner.Title.ValueString(); got != "Owner" {
+					t.Errorf("expected title %q, got %q", "Owner", got)
+				}
+				if got := owner.Description.ValueString(); got != "The team owning the example" {
+					t.Errorf("expected description %q, got %q", "The team owning the example", got)
+				}
+				var entity entitySchemaPropertyEntity
+				if diags := owner.Entity.As(ctx, &entity, basetypes.ObjectAsOptions{}); diags.HasError() {
+					t.Fatalf("decoding entity: %v", diags)
+				}
+				if got := entity.Type.ValueString(); got != "terraform:team" {
+					t.Errorf("expected relation to %q, got %q", "terraform:team", got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func (r *entitySchemaResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		entitySchemaRelationValidator{},
+	}
+}
+
+// entitySchemaRelationValidator validates that the `entity` attribute of a
+// property is set exactly when its type is `relation`, and that it references
+// a Terraform entity schema type.
+//
+// Property names don't need validating, as `properties` is a map keyed by
+// name, which makes them unique by construction.
+type entitySchemaRelationValidator struct{}
+
+func (v entitySchemaRelationValidator) Description(ctx context.Context) string {
+	return "entity must be set exactly when type is relation, and entity.type must be prefixed with terraform:"
+}
+
+func (v entitySchemaRelationValidator) MarkdownDescription(ctx context.Context) string {
+	return "`entity` must be set exactly when `type` is `relation`, and `entity.type` must be prefixed with `terraform:`"
+}
+
+func (v entitySchemaRelationValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var properties types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("properties"), &properties)...)
+	if resp.Diagnostics.HasError() || properties.IsNull() || properties.IsUnknown() {
+		return
+	}
+
+	for name, value := range properties.Elements() {
+		propValue, ok := value.(types.Object)
+		if !ok || propValue.IsNull() || propValue.IsUnknown() {
+			continue
+		}
+
+		var prop entitySchemaProperty
+		diags := propValue.As(ctx, &prop, basetypes.ObjectAsOptions{})
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		propPath := path.Root("properties").AtMapKey(name)
+		isRelation := prop.Type.ValueString() == "relation"
+
+		switch {
+		case prop.Type.IsUnknown() || prop.Entity.IsUnknown():
+			continue
+		case isRelation && prop.Entity.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				propPath.AtName("entity"),
+				"Missing relation entity",
+				fmt.Sprintf("Property %q has type `relation`, so `entity` must be set to the entity schema it relates to.", name),
+			)
+			continue
+		case !isRelation && !prop.Entity.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				propPath.AtName("entity"),
+				"Unexpected relation entity",
+				fmt.Sprintf("Property %q has type %q, but `entity` can only be set when the type is `relation`.", name, prop.Type.ValueString()),
+			)
+			continue
+		case !isRelation:
+			continue
+		}
+
+		var entity entitySchemaPropertyEntity
+		diags = prop.Entity.As(ctx, &entity, basetypes.ObjectAsOptions{})
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+		if !entity.Type.IsUnknown() && !entitySchemaTypeRegExp.MatchString(entity.Type.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				propPath.AtName("entity").AtName("type"),
+				"Invalid relation entity type",
+				fmt.Sprintf("Property %q relates to entity schema type %q, which must be prefixed with `terraform:`.", name, entity.Type.ValueString()),
+			)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	gitbook "github.com/GitbookIO/go-gitbook/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func NewEntitySchemasDataSource() datasource.DataSource {
+	return &entitySchemasDataSource{}
+}
+
+// entitySchemasDataSource defines the data source implementation.
+type entitySchemasDataSource struct {
+	client *gitbook.OrganizationsApiService
+}
+
+type entitySchemasDataSourceModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+	EntitySchemas  types.List   `tfsdk:"entity_schemas"`
+}
+
+var entitySchemasEntitySchemaAttributeTypes = map[string]attr.Type{
+	"type":       types.StringType,
+	"title":      types.ObjectType{AttrTypes: entitySchemaTitleAttributeTypes},
+	"properties": types.MapType{ElemType: types.ObjectType{AttrTypes: entitySchemaDataSourcePropertyAttributeTypes}},
+}
+
+func (d *entitySchemasDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entity_schemas"
+}
+
+func (d *entitySchemasDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the entity schemas of an organization.",
+
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the organization that owns the entity schemas.",
+			},
+			"entity_schemas": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The entity schemas of the organization, in the order returned by GitBook.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The type of the entity schema.",
+						},
+						"title": schema.SingleNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "The title of the entity schema.",
+							Attributes: map[string]schema.Attribute{
+								"singular": schema.StringAttribute{
+									Computed: true,
+								},
+								"plural": schema.StringAttribute{
+									Computed: true,
+								},
+							},
+						},
+						"properties": entitySchemaPropertiesDataSourceAttribute(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *entitySchemasDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*gitBookClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *gitBookClient, got: %T. Please report this issue to GitBook.", req.ProviderData),
+		)
+
+		return
+	}
+
+	client.addTokenError(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.client = client.OrganizationsApi
+}
+
+func (d *entitySchemasDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model entitySchemasDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := model.OrganizationID.ValueString()
+
+	// List the entity schemas via the GitBook API, paging through the results.
+	entitySchemas, err := listEntitySchemas(ctx, d.client, organizationID)
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error listing GitBook entity schemas",
+			fmt.Sprintf("Could not list GitBook entity schemas of organization %q", organizationID),
+			err,
+		)
+		return
+	}
+
+	values := make([]attr.Value, 0, len(entitySchemas))
+	for _, entitySchema := range entitySchemas {
+		entitySchemaModel := entitySchemaDataSourceModel{OrganizationID: model.OrganizationID}
+		entitySchemaModel.parseEntitySchema(&entitySchema, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		value, diags := types.ObjectValue(entitySchemasEntitySchemaAttributeTypes, map[string]attr.Value{
+			"type":       entitySchemaModel.Type,
+			"title":      entitySchemaModel.Title,
+			"properties": entitySchemaModel.Properties,
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		values = append(values, value)
+	}
+
+	entitySchemasValue, diags := types.ListValue(types.ObjectType{AttrTypes: entitySchemasEntitySchemaAttributeTypes}, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	model.EntitySchemas = entitySchemasValue
+
+	// Save data into Terraform state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
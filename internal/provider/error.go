@@ -1,15 +1,203 @@
 package provider
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 
 	gitbook "github.com/GitbookIO/go-gitbook/api"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-func parseErrorMessage(err error) string {
-	var openAPIErr *gitbook.GenericOpenAPIError
-	if errors.As(err, &openAPIErr) {
-		return string(openAPIErr.Body())
+// apiErrorKind classifies errors returned by the GitBook API.
+type apiErrorKind string
+
+const (
+	apiErrorKindUnknown     apiErrorKind = "unknown"
+	apiErrorKindAuth        apiErrorKind = "auth"
+	apiErrorKindNotFound    apiErrorKind = "not_found"
+	apiErrorKindConflict    apiErrorKind = "conflict"
+	apiErrorKindRateLimited apiErrorKind = "rate_limited"
+	apiErrorKindValidation  apiErrorKind = "validation"
+	apiErrorKindServer      apiErrorKind = "server"
+)
+
+// apiError is an error returned by the GitBook API, decoded from its error
+// envelope, e.g. `{"error":{"code":400,"message":"..."}}`.
+type apiError struct {
+	Status    int
+	Code      int
+	Message   string
+	RequestID string
+	Kind      apiErrorKind
+
+	// Property is the name of the entity property the error is about, if any.
+	Property string
+}
+
+type apiErrorEnvelope struct {
+	Error struct {
+		Code      int    `json:"code"`
+		Message   string `json:"message"`
+		RequestID string `json:"requestId"`
+	} `json:"error"`
+}
+
+var (
+	apiErrorStatusRegExp = regexp.MustCompile(`^(\d{3}) `)
+	// apiErrorPropertyRegExp matches a property name in an error message, only
+	// when quoted (`property "arn"`) or dotted (`properties.arn`), to not
+	// mistake ordinary words (`property is required`) for a property name.
+	apiErrorPropertyRegExp = regexp.MustCompile("propert(?:y|ies)(?:\\.([\\w-]+)| [\"'`]([\\w-]+)[\"'`])")
+)
+
+// apiResponseError is an error from an HTTP response of the GitBook API, with
+// the HTTP status (e.g. `404 Not Found`) as error string. It's implemented by
+// the errors of the GitBook API client, and by `spaceAPIError`.
+type apiResponseError interface {
+	error
+	Body() []byte
+}
+
+var _ apiResponseError = (*gitbook.GenericOpenAPIError)(nil)
+
+// parseAPIError decodes an error returned by the GitBook API client. Errors
+// that didn't originate from an API response are returned as an unknown kind,
+// with the error string as message.
+func parseAPIError(err error) *apiError {
+	var openAPIErr apiResponseError
+	if !errors.As(err, &openAPIErr) {
+		return &apiError{
+			Message: err.Error(),
+			Kind:    apiErrorKindUnknown,
+		}
+	}
+
+	result := &apiError{
+		Message: strings.TrimSpace(string(openAPIErr.Body())),
 	}
-	return err.Error()
+
+	var envelope apiErrorEnvelope
+	if json.Unmarshal(openAPIErr.Body(), &envelope) == nil && envelope.Error.Message != "" {
+		result.Code = envelope.Error.Code
+		result.Message = envelope.Error.Message
+		result.RequestID = envelope.Error.RequestID
+	}
+
+	// The client reports the HTTP status (e.g. `404 Not Found`) as the error
+	// string, falling back to the code of the error envelope.
+	if match := apiErrorStatusRegExp.FindStringSubmatch(openAPIErr.Error()); match != nil {
+		result.Status, _ = strconv.Atoi(match[1])
+	} else {
+		result.Status = result.Code
+	}
+	if result.Message == "" {
+		result.Message = openAPIErr.Error()
+	}
+
+	switch {
+	case result.Status == 401 || result.Status == 403:
+		result.Kind = apiErrorKindAuth
+	case result.Status == 404:
+		result.Kind = apiErrorKindNotFound
+	case result.Status == 409:
+		result.Kind = apiErrorKindConflict
+	case result.Status == 429:
+		result.Kind = apiErrorKindRateLimited
+	case result.Status == 400 || result.Status == 422:
+		result.Kind = apiErrorKindValidation
+	case result.Status >= 500:
+		result.Kind = apiErrorKindServer
+	default:
+		result.Kind = apiErrorKindUnknown
+	}
+
+	if result.Kind == apiErrorKindValidation {
+		if match := apiErrorPropertyRegExp.FindStringSubmatch(result.Message); match != nil {
+			result.Property = match[1] + match[2]
+		}
+	}
+
+	return result
+}
+
+// hint returns an actionable suggestion for the kind of error.
+func (e *apiError) hint() string {
+	switch e.Kind {
+	case apiErrorKindAuth:
+		return "Check that the GitBook Terraform integration is installed on the organization, " +
+			"and that the access token is valid."
+	case apiErrorKindNotFound:
+		return "Check that the organization ID and type are correct, and that the object still exists in GitBook."
+	case apiErrorKindConflict:
+		return "The object was modified concurrently or already exists. Refresh the state and try again."
+	case apiErrorKindRateLimited:
+		return "The GitBook API rate limit was exceeded. Wait a moment and try again, or reduce parallelism."
+	case apiErrorKindValidation:
+		return "Check the configuration against the entity schema."
+	case apiErrorKindServer:
+		return "GitBook encountered an internal error. Try again later; if the error persists, please contact GitBook support."
+	default:
+		return "If the error is not clear, please contact GitBook support."
+	}
+}
+
+// String formats the error for diagnostics.
+func (e *apiError) String() string {
+	var details []string
+	if e.Status != 0 {
+		details = append(details, fmt.Sprintf("status: %d", e.Status))
+	}
+	if e.RequestID != "" {
+		details = append(details, fmt.Sprintf("request ID: %s", e.RequestID))
+	}
+	if len(details) == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("%s (%s)", e.Message, strings.Join(details, ", "))
+}
+
+// isNotFoundError reports whether an error returned by the GitBook API client
+// means the requested object doesn't exist.
+func isNotFoundError(err error) bool {
+	return err != nil && parseAPIError(err).Kind == apiErrorKindNotFound
+}
+
+// isRetryableError reports whether a request that failed with an error
+// returned by the GitBook API client may succeed when retried.
+func isRetryableError(err error) bool {
+	switch parseAPIError(err).Kind {
+	case apiErrorKindNotFound, apiErrorKindRateLimited, apiErrorKindServer, apiErrorKindUnknown:
+		return true
+	default:
+		return false
+	}
+}
+
+// addAPIError adds an error diagnostic for an error returned by the GitBook
+// API client.
+func addAPIError(diags *diag.Diagnostics, summary, detail string, err error) {
+	apiErr := parseAPIError(err)
+	diags.AddError(summary, formatAPIErrorDetail(detail, apiErr))
+}
+
+// addPropertiesAPIError adds an error diagnostic for an error returned by the
+// GitBook API client when writing properties. Validation errors about one of
+// the given properties are scoped to its key of the `properties` attribute.
+func addPropertiesAPIError(diags *diag.Diagnostics, properties types.Map, summary, detail string, err error) {
+	apiErr := parseAPIError(err)
+	if _, ok := properties.Elements()[apiErr.Property]; ok && apiErr.Property != "" {
+		diags.AddAttributeError(path.Root("properties").AtMapKey(apiErr.Property), summary, formatAPIErrorDetail(detail, apiErr))
+		return
+	}
+	diags.AddError(summary, formatAPIErrorDetail(detail, apiErr))
+}
+
+func formatAPIErrorDetail(detail string, apiErr *apiError) string {
+	return fmt.Sprintf("%s: %s\n\n%s", detail, apiErr, apiErr.hint())
 }
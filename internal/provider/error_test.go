@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gitbook "github.com/GitbookIO/go-gitbook/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newTestAPIError returns the error of the GitBook API client for an API
+// response with the given status, content type and body.
+func newTestAPIError(t *testing.T, status int, contentType, body string) error {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	}))
+	defer server.Close()
+
+	config := gitbook.NewConfiguration()
+	config.Servers = gitbook.ServerConfigurations{{URL: server.URL}}
+	_, _, err := gitbook.NewAPIClient(config).OrganizationsApi.GetOrganizationById(context.Background(), "org").Execute()
+	var openAPIErr *gitbook.GenericOpenAPIError
+	if !errors.As(err, &openAPIErr) {
+		t.Fatalf("expected a GitBook API client error for status %d, got %v", status, err)
+	}
+	return err
+}
+
+// newTestJSONAPIError returns the error of the GitBook API client for a JSON
+// API response with the given status and body.
+func newTestJSONAPIError(t *testing.T, status int, body string) error {
+	t.Helper()
+	return newTestAPIError(t, status, "application/json", body)
+}
+
+func TestParseAPIError(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want apiError
+	}{
+		"envelope": {
+			err: newTestJSONAPIError(t, 400, `{"error":{"code":400,"message":"Invalid request","requestId":"req_1"}}`),
+			want: apiError{
+				Status:    400,
+				Code:      400,
+				Message:   "Invalid request",
+				RequestID: "req_1",
+				Kind:      apiErrorKindValidation,
+			},
+		},
+		"empty body": {
+			err: newTestJSONAPIError(t, 404, ""),
+			want: apiError{
+				Status:  404,
+				Message: "404 Not Found",
+				Kind:    apiErrorKindNotFound,
+			},
+		},
+		// The client replaces the status with the decoding error when the body
+		// isn't JSON, so the status can only be taken from the envelope code.
+		"status from the envelope code": {
+			err: newTestAPIError(t, 409, "text/plain", `{"error":{"code":409,"message":"Conflict"}}`),
+			want: apiError{
+				Status:  409,
+				Code:    409,
+				Message: "Conflict",
+				Kind:    apiErrorKindConflict,
+			},
+		},
+		"body without envelope": {
+			err: newTestAPIError(t, 502, "text/html", "upstream unavailable\n"),
+			want: apiError{
+				Message: "upstream unavailable",
+				Kind:    apiErrorKindUnknown,
+			},
+		},
+		"status from the error string over the envelope code": {
+			err: newTestJSONAPIError(t, 422, `{"error":{"code":400,"message":"Invalid"}}`),
+			want: apiError{
+				Status:  422,
+				Code:    400,
+				Message: "Invalid",
+				Kind:    apiErrorKindValidation,
+			},
+		},
+		"unauthorized": {
+			err:  newTestJSONAPIError(t, 401, `{"error":{"code":401,"message":"Unauthorized"}}`),
+			want: apiError{Status: 401, Code: 401, Message: "Unauthorized", Kind: apiErrorKindAuth},
+		},
+		"forbidden": {
+			err:  newTestJSONAPIError(t, 403, `{"error":{"code":403,"message":"Forbidden"}}`),
+			want: apiError{Status: 403, Code: 403, Message: "Forbidden", Kind: apiErrorKindAuth},
+		},
+		"rate limited": {
+			err:  newTestJSONAPIError(t, 429, `{"error":{"code":429,"message":"Slow down"}}`),
+			want: apiError{Status: 429, Code: 429, Message: "Slow down", Kind: apiErrorKindRateLimited},
+		},
+		"server": {
+			err:  newTestJSONAPIError(t, 500, `{"error":{"code":500,"message":"Oops"}}`),
+			want: apiError{Status: 500, Code: 500, Message: "Oops", Kind: apiErrorKindServer},
+		},
+		"other status": {
+			err:  newTestJSONAPIError(t, 418, `{"error":{"code":418,"message":"Teapot"}}`),
+			want: apiError{Status: 418, Code: 418, Message: "Teapot", Kind: apiErrorKindUnknown},
+		},
+		"not an API error": {
+			err:  errors.New("connection refused"),
+			want: apiError{Message: "connection refused", Kind: apiErrorKindUnknown},
+		},
+		"quoted property": {
+			err:  newTestJSONAPIError(t, 400, `{"error":{"code":400,"message":"Invalid value for property \"arn\""}}`),
+			want: apiError{Status: 400, Code: 400, Message: `Invalid value for property "arn"`, Kind: apiErrorKindValidation, Property: "arn"},
+		},
+		"dotted property": {
+			err:  newTestJSONAPIError(t, 400, `{"error":{"code":400,"message":"properties.arn must be a string"}}`),
+			want: apiError{Status: 400, Code: 400, Message: "properties.arn must be a string", Kind: apiErrorKindValidation, Property: "arn"},
+		},
+		"property in ordinary words": {
+			err:  newTestJSONAPIError(t, 400, `{"error":{"code":400,"message":"property is required"}}`),
+			want: apiError{Status: 400, Code: 400, Message: "property is required", Kind: apiErrorKindValidation},
+		},
+		"properties in ordinary words": {
+			err:  newTestJSONAPIError(t, 400, `{"error":{"code":400,"message":"properties must be an object"}}`),
+			want: apiError{Status: 400, Code: 400, Message: "properties must be an object", Kind: apiErrorKindValidation},
+		},
+		"property type": {
+			err:  newTestJSONAPIError(t, 400, `{"error":{"code":400,"message":"Unknown property type foo"}}`),
+			want: apiError{Status: 400, Code: 400, Message: "Unknown property type foo", Kind: apiErrorKindValidation},
+		},
+		"property of a non-validation error": {
+			err:  newTestJSONAPIError(t, 500, `{"error":{"code":500,"message":"Failed to index property \"arn\""}}`),
+			want: apiError{Status: 500, Code: 500, Message: `Failed to index property "arn"`, Kind: apiErrorKindServer},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := parseAPIError(test.err)
+			if *got != test.want {
+				t.Errorf("expected %+v, got %+v", test.want, *got)
+			}
+		})
+	}
+}
+
+func TestAddPropertiesAPIError(t *testing.T) {
+	properties := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"arn": types.StringValue("arn:aws:s3:::example"),
+	})
+
+	tests := map[string]struct {
+		message  string
+		wantPath path.Path
+	}{
+		"planned property": {
+			message:  `Invalid value for property "arn"`,
+			wantPath: path.Root("properties").AtMapKey("arn"),
+		},
+		"unknown property": {
+			message:  `Invalid value for property "name"`,
+			wantPath: path.Empty(),
+		},
+		"no property": {
+			message:  "property is required",
+			wantPath: path.Empty(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			message, _ := json.Marshal(test.message)
+			err := newTestJSONAPIError(t, 400, `{"error":{"code":400,"message":`+string(message)+`}}`)
+			addPropertiesAPIError(&diags, properties, "Error", "Could not write", err)
+
+			if len(diags) != 1 {
+				t.Fatalf("expected 1 diagnostic, got %d", len(diags))
+			}
+			gotPath := path.Empty()
+			if withPath, ok := diags[0].(diag.DiagnosticWithPath); ok {
+				gotPath = withPath.Path()
+			}
+			if !gotPath.Equal(test.wantPath) {
+				t.Errorf("expected path %q, got %q", test.wantPath, gotPath)
+			}
+		})
+	}
+}
+
+func TestAddAPIError(t *testing.T) {
+	var diags diag.Diagnostics
+	err := newTestJSONAPIError(t, 404, `{"error":{"code":404,"message":"Organization not found","requestId":"req_1"}}`)
+	addAPIError(&diags, "Error reading GitBook organization", "Could not fetch GitBook organization", err)
+
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d", len(diags))
+	}
+	if _, ok := diags[0].(diag.DiagnosticWithPath); ok {
+		t.Errorf("expected the diagnostic not to be scoped to an attribute")
+	}
+	if got := diags[0].Summary(); got != "Error reading GitBook organization" {
+		t.Errorf("expected summary %q, got %q", "Error reading GitBook organization", got)
+	}
+	want := "Could not fetch GitBook organization: Organization not found (status: 404, request ID: req_1)\n\n" + (&apiError{Kind: apiErrorKindNotFound}).hint()
+	if got := diags[0].Detail(); got != want {
+		t.Errorf("expected detail %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func NewHealthDataSource() datasource.DataSource {
+	return &healthDataSource{}
+}
+
+// healthDataSource defines the data source implementation.
+type healthDataSource struct {
+	client *gitBookClient
+}
+
+type healthDataSourceModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+	EntityTypes    types.List   `tfsdk:"entity_types"`
+	OK             types.Bool   `tfsdk:"ok"`
+	LatencyMs      types.Int64  `tfsdk:"latency_ms"`
+	Errors         types.List   `tfsdk:"errors"`
+	Checks         types.List   `tfsdk:"checks"`
+}
+
+var healthCheckAttributeTypes = map[string]attr.Type{
+	"name":       types.StringType,
+	"ok":         types.BoolType,
+	"latency_ms": types.Int64Type,
+	"error":      types.StringType,
+}
+
+// healthCheck is the result of a single check of the health data source.
+type healthCheck struct {
+	Name    string
+	Latency time.Duration
+	Err     error
+}
+
+func (d *healthDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_health"
+}
+
+func (d *healthDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Checks that the GitBook integration works: the token exchange with the Terraform integration, " +
+			"an authenticated call to the GitBook API, and optionally read access to entity types. " +
+			"Failures are reported in `ok` and `errors` rather than failing the read, so the data source fits `check` blocks. " +
+			"Unlike other resources and data sources, it doesn't fail when the provider can't obtain an installation token.",
+
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The ID of an organization to check access to. Required when `entity_types` is set.",
+			},
+			"entity_types": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The types of entity schemas to check read access to, by reading the entity schema and listing its entities.",
+				Validators: []validator.List{
+					listvalidator.AlsoRequires(path.MatchRoot("organization_id")),
+				},
+			},
+			"ok": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether all the checks succeeded.",
+			},
+			"latency_ms": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The total duration of the checks, in milliseconds.",
+			},
+			"errors": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The errors of the failed checks, prefixed with the name of the check.",
+			},
+			"checks": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The result of every check, in the order they were performed.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed: true,
+							MarkdownDescription: "The name of the check: `token_exchange`, `api`, `organization`, " +
+								"or `entity_schema:<type>` and `entities:<type>` for each entity type.",
+						},
+						"ok": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the check succeeded.",
+						},
+						"latency_ms": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The duration of the check, in milliseconds.",
+						},
+						"error": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The error of the check, null if it succeeded.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *healthDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*gitBookClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *gitBookClient, got: %T. Please report this issue to GitBook.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *healthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model healthDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var entityTypes []string
+	if !model.EntityTypes.IsNull() {
+		resp.Diagnostics.Append(model.EntityTypes.ElementsAs(ctx, &entityTypes, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Every check is performed, even when a previous one failed, so that the
+	// results describe everything that is broken.
+	var checks []healthCheck
+	check := func(name string, fn func() error) {
+		start := time.Now()
+		err := fn()
+		checks = append(checks, healthCheck{Name: name, Latency: time.Since(start), Err: err})
+		if err != nil {
+			tflog.Warn(ctx, "GitBook health check failed", map[string]interface{}{"check": name, "error": err.Error()})
+		}
+	}
+
+	// The exchange of the provider configuration is reported if it failed,
+	// and otherwise checked again, as the integration token may have been
+	// revoked since.
+	check("token_exchange", func() error {
+		if d.client.tokenErr != nil {
+			return d.client.tokenErr
+		}
+		_, err := d.client.tokenExchange(ctx)
+		return err
+	})
+	check("api", func() error {
+		_, _, err := d.client.OrganizationsApi.ListOrganizationsForAuthenticatedUser(ctx).Limit(1).Execute()
+		return err
+	})
+	if !model.OrganizationID.IsNull() {
+		organizationID := model.OrganizationID.ValueString()
+		check("organization", func() error {
+			_, _, err := d.client.OrganizationsApi.GetOrganizationById(ctx, organizationID).Execute()
+			return err
+		})
+		for _, entityType := range entityTypes {
+			check("entity_schema:"+entityType, func() error {
+				_, _, err := d.client.OrganizationsApi.GetEntitySchema(ctx, organizationID, entityType).Execute()
+				return err
+			})
+			check("entities:"+entityType, func() error {
+				_, _, err := d.client.OrganizationsApi.ListSchemaEntities(ctx, organizationID, entityType).Limit(1).Execute()
+				return err
+			})
+		}
+	}
+
+	ok := true
+	var latency time.Duration
+	errorValues := []attr.Value{}
+	checkValues := make([]attr.Value, 0, len(checks))
+	for _, c := range checks {
+		latency += c.Latency
+		errorValue := types.StringNull()
+		if c.Err != nil {
+			ok = false
+			message := parseAPIError(c.Err).String()
+			errorValue = types.StringValue(message)
+			errorValues = append(errorValues, types.StringValue(c.Name+": "+message))
+		}
+
+		value, diags := types.ObjectValue(healthCheckAttributeTypes, map[string]attr.Value{
+			"name":       types.StringValue(c.Name),
+			"ok":         types.BoolValue(c.Err == nil),
+			"latency_ms": types.Int64Value(c.Latency.Milliseconds()),
+			"error":      errorValue,
+		})
+		resp.Diagnostics.Append(diags...)
+		checkValues = append(checkValues, value)
+	}
+
+	errorsValue, diags := types.ListValue(types.StringType, errorValues)
+	resp.Diagnostics.Append(diags...)
+	checksValue, diags := types.ListValue(types.ObjectType{AttrTypes: healthCheckAttributeTypes}, checkValues)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.OK = types.BoolValue(ok)
+	model.LatencyMs = types.Int64Value(latency.Milliseconds())
+	model.Errors = errorsValue
+	model.Checks = checksValue
+
+	// Save data into Terraform state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
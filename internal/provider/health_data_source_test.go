@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestHealthDataSourceReadTokenError(t *testing.T) {
+	ctx := context.Background()
+	api := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"items":[]}`)
+	})
+	exchanges := 0
+	d := &healthDataSource{client: &gitBookClient{
+		APIClient: newTestAPIClient(t, api),
+		tokenErr: &tokenExchangeError{
+			Summary: "Invalid integration access token used",
+			Detail:  "The provided GitBook Terraform integration access token is invalid.",
+		},
+		tokenExchange: func(ctx context.Context) (string, error) {
+			exchanges++
+			return "token", nil
+		},
+	}}
+
+	config := healthDataSourceModel{
+		OrganizationID: types.StringNull(),
+		EntityTypes:    types.ListNull(types.StringType),
+		OK:             types.BoolNull(),
+		LatencyMs:      types.Int64Null(),
+		Errors:         types.ListNull(types.StringType),
+		Checks:         types.ListNull(types.ObjectType{AttrTypes: healthCheckAttributeTypes}),
+	}
+	req := datasource.ReadRequest{Config: newTestDataSourceConfig(t, d, config)}
+	resp := datasource.ReadResponse{State: newTestNullDataSourceState(d)}
+	d.Read(ctx, req, &resp)
+
+	// The failed exchange is reported by the data source, not as an error.
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if exchanges != 0 {
+		t.Errorf("expected the failed token exchange not to be retried, got %d exchanges", exchanges)
+	}
+
+	var model healthDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("reading state: %v", resp.Diagnostics)
+	}
+	if !model.OK.Equal(types.BoolValue(false)) {
+		t.Errorf("expected ok to be false, got %s", model.OK)
+	}
+
+	var errors []string
+	resp.Diagnostics.Append(model.Errors.ElementsAs(ctx, &errors, false)...)
+	if len(errors) != 1 || !strings.HasPrefix(errors[0], "token_exchange: Invalid integration access token used") {
+		t.Errorf("expected a single token exchange error, got %q", errors)
+	}
+	if len(model.Checks.Elements()) != 2 {
+		t.Errorf("expected the token exchange and API checks, got %s", model.Checks)
+	}
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gitbook "github.com/GitbookIO/go-gitbook/api"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// newTestAPIClient returns a GitBook API client sending its requests to the
+// given handler, which serves the API from its root.
+func newTestAPIClient(t *testing.T, handler http.Handler) *gitbook.APIClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config := gitbook.NewConfiguration()
+	config.Servers = gitbook.ServerConfigurations{{URL: server.URL}}
+	return gitbook.NewAPIClient(config)
+}
+
+// newTestNullResourceState returns the null state of a resource, as when
+// creating it.
+func newTestNullResourceState(r resource.Resource) tfsdk.State {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	return tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+}
+
+// newTestResourceState returns the state of a resource holding the model.
+func newTestResourceState(t *testing.T, r resource.Resource, model interface{}) tfsdk.State {
+	t.Helper()
+	state := newTestNullResourceState(r)
+	if diags := state.Set(context.Background(), model); diags.HasError() {
+		t.Fatalf("setting state: %v", diags)
+	}
+	return state
+}
+
+// newTestResourcePlan returns the plan of a resource holding the model.
+func newTestResourcePlan(t *testing.T, r resource.Resource, model interface{}) tfsdk.Plan {
+	t.Helper()
+	state := newTestResourceState(t, r, model)
+	return tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+}
+
+// newTestNullDataSourceState returns the null state of a data source, as when
+// reading it.
+func newTestNullDataSourceState(d datasource.DataSource) tfsdk.State {
+	ctx := context.Background()
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	return tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+}
+
+// newTestDataSourceConfig returns the configuration of a data source holding
+// the model.
+func newTestDataSourceConfig(t *testing.T, d datasource.DataSource, model interface{}) tfsdk.Config {
+	t.Helper()
+	state := newTestNullDataSourceState(d)
+	if diags := state.Set(context.Background(), model); diags.HasError() {
+		t.Fatalf("setting config: %v", diags)
+	}
+	return tfsdk.Config{Schema: state.Schema, Raw: state.Raw}
+}
@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// terraformIntegrationName is the name of the GitBook Terraform integration,
+// used when the installation token doesn't name its integration.
+const terraformIntegrationName = "terraform"
+
+func NewInstallationDataSource() datasource.DataSource {
+	return &installationDataSource{}
+}
+
+// installationDataSource defines the data source implementation.
+type installationDataSource struct {
+	client *gitBookClient
+}
+
+type installationDataSourceModel struct {
+	InstallationID    types.String `tfsdk:"installation_id"`
+	Integration       types.String `tfsdk:"integration"`
+	OrganizationID    types.String `tfsdk:"organization_id"`
+	Target            types.String `tfsdk:"target"`
+	Status            types.String `tfsdk:"status"`
+	Scopes            types.List   `tfsdk:"scopes"`
+	IntegrationScopes types.List   `tfsdk:"integration_scopes"`
+	ExpiresAt         types.String `tfsdk:"expires_at"`
+	Claims            types.String `tfsdk:"claims"`
+}
+
+func (d *installationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_installation"
+}
+
+func (d *installationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Introspects the GitBook integration installation that the provider's access token was issued for. " +
+			"Attributes are decoded from the short-lived installation token, and completed with the GitBook API " +
+			"when the token is allowed to read its installation.",
+
+		Attributes: map[string]schema.Attribute{
+			"installation_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the integration installation.",
+			},
+			"integration": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the integration.",
+			},
+			"organization_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the organization the integration is installed in, if installed in an organization.",
+			},
+			"target": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "What the integration is installed in, either `organization` or `user`.",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the installation: `active`, `pending` or `paused`. Null if it can't be read from the GitBook API.",
+			},
+			"scopes": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The scopes granted to the installation token, e.g. `entities:write`. Null if the token has no scope claims.",
+			},
+			"integration_scopes": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "The scopes requested by the integration, which the installation may not have been granted yet. " +
+					"Null if the integration can't be read from the GitBook API.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The expiry of the installation token, in RFC 3339 format.",
+			},
+			"claims": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "All the claims of the installation token, as a JSON object.",
+			},
+		},
+	}
+}
+
+func (d *installationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*gitBookClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *gitBookClient, got: %T. Please report this issue to GitBook.", req.ProviderData),
+		)
+
+		return
+	}
+
+	client.addTokenError(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.client = client
+}
+
+func (d *installationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model installationDataSourceModel
+
+	claims, err := parseInstallationTokenClaims(d.client.token)
+	if err != nil {
+		addInstallationTokenDecodeError(&resp.Diagnostics, err)
+		return
+	}
+
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to encode GitBook installation token claims", err.Error())
+		return
+	}
+	model.Claims = types.StringValue(string(claimsJSON))
+
+	installationID := claims.InstallationID()
+	integration := claims.Integration()
+	organizationID := claims.OrganizationID()
+	target := ""
+	switch {
+	case organizationID != "":
+		target = "organization"
+	case claims.String("target.user", "user") != "":
+		target = "user"
+	}
+	scopes := claims.Strings("scopes", "scope")
+
+	model.InstallationID = stringValueOrNull(installationID)
+	model.Integration = types.StringValue(integration)
+	model.Status = types.StringNull()
+	model.ExpiresAt = types.StringNull()
+	if expiresAt, ok := claims.ExpiresAt(); ok {
+		model.ExpiresAt = types.StringValue(expiresAt.Format(time.RFC3339))
+	}
+
+	// Complete the claims with the installation and its integration, which the
+	// token may not be allowed to read.
+	if installationID != "" {
+		installation, _, err := d.client.IntegrationsApi.GetIntegrationInstallationById(ctx, integration, installationID).Execute()
+		if err == nil {
+			model.Status = types.StringValue(string(installation.Status))
+			if installation.Target.OrganizationTarget != nil {
+				organizationID = installation.Target.OrganizationTarget.Organization
+				target = "organization"
+			} else if installation.Target.UserTarget != nil {
+				target = "user"
+			}
+		} else {
+			addInstallationIntrospectionWarning(&resp.Diagnostics, "installation", err)
+		}
+	}
+
+	// The scopes requested by the integration aren't necessarily granted to
+	// the installation, so they're kept apart from the token's scopes.
+	var integrationScopes []string
+	integrationInfo, _, err := d.client.IntegrationsApi.GetIntegrationByName(ctx, integration).Execute()
+	if err == nil {
+		integrationScopes = make([]string, len(integrationInfo.Scopes))
+		for i, scope := range integrationInfo.Scopes {
+			integrationScopes[i] = string(scope)
+		}
+	} else {
+		addInstallationIntrospectionWarning(&resp.Diagnostics, "integration scopes", err)
+	}
+
+	model.OrganizationID = stringValueOrNull(organizationID)
+	model.Target = stringValueOrNull(target)
+	model.Scopes = stringListValueOrNull(scopes, &resp.Diagnostics)
+	model.IntegrationScopes = stringListValueOrNull(integrationScopes, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func addInstallationTokenDecodeError(diags *diag.Diagnostics, err error) {
+	diags.AddError(
+		"Unable to decode GitBook installation token",
+		"The short-lived GitBook API access token obtained from the Terraform integration could not be decoded. "+
+			"If the error persists, please contact GitBook support.\n\n"+
+			"Decoding error: "+err.Error(),
+	)
+}
+
+func addInstallationIntrospectionWarning(diags *diag.Diagnostics, what string, err error) {
+	diags.AddWarning(
+		"Unable to read GitBook installation details",
+		fmt.Sprintf("The %s could not be read from the GitBook API, so only the claims of the installation token are used.\n\n%s", what, parseAPIError(err).String()),
+	)
+}
+
+// stringValueOrNull returns a null string for an empty string.
+func stringValueOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// stringListValueOrNull returns a null list for a nil slice.
+func stringListValueOrNull(values []string, diags *diag.Diagnostics) types.List {
+	if values == nil {
+		return types.ListNull(types.StringType)
+	}
+	elements := make([]attr.Value, len(values))
+	for i, value := range values {
+		elements[i] = types.StringValue(value)
+	}
+	list, d := types.ListValue(types.StringType, elements)
+	diags.Append(d...)
+	return list
+}
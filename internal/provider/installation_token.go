@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// tokenExchangeError is an error obtaining a short-lived installation token,
+// described for a diagnostic.
+type tokenExchangeError struct {
+	Summary string
+	Detail  string
+}
+
+func (e *tokenExchangeError) Error() string {
+	return e.Summary + ": " + e.Detail
+}
+
+// exchangeIntegrationToken obtains a short-lived (installation scoped) GitBook
+// API access token from the Terraform integration, using the long-lived
+// integration access token.
+func exchangeIntegrationToken(ctx context.Context, integrationURL, accessToken, userAgent string) (string, error) {
+	tokenReq, err := http.NewRequestWithContext(ctx, http.MethodGet, integrationURL, nil)
+	if err != nil {
+		return "", &tokenExchangeError{
+			Summary: "Unable to create HTTP request to obtain GitBook API access token",
+			Detail: "An unexpected error occurred when constructing an HTTP request to obtain a short-lived GitBook API access token. " +
+				"If the error is not clear, please contact GitBook support.\n\n" +
+				"GitBook Terraform integration HTTP error: " + err.Error(),
+		}
+	}
+
+	tokenReq.Header.Set("Authorization", "Bearer "+accessToken)
+	tokenReq.Header.Set("User-Agent", userAgent)
+	tokenResp, err := http.DefaultClient.Do(tokenReq)
+	if err != nil {
+		return "", &tokenExchangeError{
+			Summary: "Unable to obtain short-lived GitBook API access token",
+			Detail: "An unexpected error occurred when obtaining a short-lived GitBook API access token. " +
+				"If the error is not clear, please contact GitBook support.\n\n" +
+				"GitBook Terraform integration HTTP error: " + err.Error(),
+		}
+	}
+	defer tokenResp.Body.Close()
+
+	if tokenResp.StatusCode == http.StatusForbidden {
+		return "", &tokenExchangeError{
+			Summary: "Invalid integration access token used",
+			Detail: "The provided GitBook Terraform integration access token is invalid. " +
+				"Visit the Terraform integration configuration on gitbook.com to obtain an access token.",
+		}
+	} else if tokenResp.StatusCode != http.StatusOK {
+		errBody, _ := io.ReadAll(tokenResp.Body)
+		return "", &tokenExchangeError{
+			Summary: "Unable to obtain short-lived GitBook API access token",
+			Detail: "An unexpected HTTP response was received when obtaining a short-lived GitBook API access token. " +
+				"If the error is not clear, please contact GitBook support.\n\n" +
+				fmt.Sprintf(`Status: %q. Body: %q`, tokenResp.Status, errBody),
+		}
+	}
+
+	var tokenEnvelope integrationTokenEnvelope
+	err = json.NewDecoder(tokenResp.Body).Decode(&tokenEnvelope)
+	if err != nil {
+		return "", &tokenExchangeError{
+			Summary: "Unable to parse token response from Terraform integration",
+			Detail: "An unexpected error occurred parsing the HTTP response data from the Terraform integration. " +
+				"If the error persists, please contact GitBook support.\n\n" +
+				"Parsing error: " + err.Error(),
+		}
+	}
+
+	return tokenEnvelope.Token, nil
+}
+
+// installationTokenClaims are the claims of the short-lived installation token
+// (a JWT) obtained from the Terraform integration.
+type installationTokenClaims map[string]interface{}
+
+// parseInstallationTokenClaims decodes the claims of an installation token.
+// The signature isn't verified, as the claims are only used for introspection,
+// and GitBook verifies the token on every API call.
+func parseInstallationTokenClaims(token string) (installationTokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("expected a JWT with 3 parts, got %d", len(parts))
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("could not decode JWT payload: %w", err)
+	}
+
+	var claims installationTokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("could not parse JWT claims: %w", err)
+	}
+	return claims, nil
+}
+
+// InstallationID returns the ID of the installation the token was issued for.
+func (c installationTokenClaims) InstallationID() string {
+	return c.String("installation", "installationId", "installation_id")
+}
+
+// Integration returns the name of the integration the token was issued for,
+// which is the Terraform integration unless the claims tell otherwise.
+func (c installationTokenClaims) Integration() string {
+	if integration := c.String("integration", "integrationName"); integration != "" {
+		return integration
+	}
+	return terraformIntegrationName
+}
+
+// OrganizationID returns the ID of the organization the installation targets,
+// if it targets one.
+func (c installationTokenClaims) OrganizationID() string {
+	return c.String("target.organization", "organization", "organizationId")
+}
+
+// String returns the first of the claims that is a string, where a claim can
+// be nested using dots, e.g. `target.organization`.
+func (c installationTokenClaims) String(names ...string) string {
+	for _, name := range names {
+		if value, ok := c.lookup(name).(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Strings returns a claim that is a list of strings, or a space-separated
+// string as in the OAuth `scope` claim.
+func (c installationTokenClaims) Strings(names ...string) []string {
+	for _, name := range names {
+		switch value := c.lookup(name).(type) {
+		case string:
+			return strings.Fields(value)
+		case []interface{}:
+			values := make([]string, 0, len(value))
+			for _, v := range value {
+				if s, ok := v.(string); ok {
+					values = append(values, s)
+				}
+			}
+			return values
+		}
+	}
+	return nil
+}
+
+// ExpiresAt returns the expiry of the token, from its `exp` claim.
+func (c installationTokenClaims) ExpiresAt() (time.Time, bool) {
+	exp, ok := c["exp"].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(exp), 0).UTC(), true
+}
+
+func (c installationTokenClaims) lookup(name string) interface{} {
+	var value interface{} = map[string]interface{}(c)
+	for _, key := range strings.Split(name, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[key]
+	}
+	return value
+}
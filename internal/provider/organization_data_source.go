@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func NewOrganizationDataSource() datasource.DataSource {
+	return &organizationDataSource{}
+}
+
+// organizationDataSource defines the data source implementation.
+type organizationDataSource struct {
+	client *gitBookClient
+}
+
+func (d *organizationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization"
+}
+
+func (d *organizationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Looks up a GitBook organization by ID, or the organization the provider's Terraform integration is installed in.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "The ID of the organization. When not set, the organization the provider's Terraform integration " +
+					"is installed in is used, which fails if the integration is installed for a user.",
+			},
+			"title": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the organization.",
+			},
+			"type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The type of the organization, either `business` or `community`.",
+			},
+			"use_case": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The use case of the organization, if any.",
+			},
+			"community_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The community type of the organization, if any.",
+			},
+			"email_domains": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The email domains of the organization.",
+			},
+			"urls": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"location": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The URL of the organization in the API.",
+					},
+					"app": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The URL of the organization in the GitBook app.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *organizationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*gitBookClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *gitBookClient, got: %T. Please report this issue to GitBook.", req.ProviderData),
+		)
+
+		return
+	}
+
+	client.addTokenError(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.client = client
+}
+
+func (d *organizationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model organizationModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := model.ID.ValueString()
+	if model.ID.IsNull() {
+		organizationID = d.tokenOrganizationID(ctx, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Fetch the organization via the GitBook API.
+	organization, _, err := d.client.OrganizationsApi.GetOrganizationById(ctx, organizationID).Execute()
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error reading GitBook organization",
+			fmt.Sprintf("Could not fetch GitBook organization %q", organizationID),
+			err,
+		)
+		return
+	}
+
+	model.parseOrganization(organization, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// tokenOrganizationID resolves the organization the installation token of the
+// provider was issued for, from its claims or else from its installation.
+func (d *organizationDataSource) tokenOrganizationID(ctx context.Context, diags *diag.Diagnostics) string {
+	claims, err := parseInstallationTokenClaims(d.client.token)
+	if err != nil {
+		addInstallationTokenDecodeError(diags, err)
+		return ""
+	}
+	if organizationID := claims.OrganizationID(); organizationID != "" {
+		return organizationID
+	}
+
+	installationID := claims.InstallationID()
+	if installationID == "" {
+		diags.AddAttributeError(
+			path.Root("id"),
+			"GitBook organization not found",
+			"The installation token of the provider doesn't identify its organization. Set `id` to the ID of the organization.",
+		)
+		return ""
+	}
+	installation, _, err := d.client.IntegrationsApi.GetIntegrationInstallationById(ctx, claims.Integration(), installationID).Execute()
+	if err != nil {
+		addAPIError(
+			diags,
+			"Error reading GitBook installation",
+			fmt.Sprintf("Could not fetch GitBook installation %q to resolve its organization", installationID),
+			err,
+		)
+		return ""
+	}
+	if installation.Target.OrganizationTarget == nil {
+		diags.AddAttributeError(
+			path.Root("id"),
+			"GitBook organization not found",
+			fmt.Sprintf("The GitBook installation %q of the provider isn't installed in an organization. Set `id` to the ID of the organization.", installationID),
+		)
+		return ""
+	}
+	return installation.Target.OrganizationTarget.Organization
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+
+	gitbook "github.com/GitbookIO/go-gitbook/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type organizationModel struct {
+	ID            types.String `tfsdk:"id"`
+	Title         types.String `tfsdk:"title"`
+	Type          types.String `tfsdk:"type"`
+	UseCase       types.String `tfsdk:"use_case"`
+	CommunityType types.String `tfsdk:"community_type"`
+	EmailDomains  types.List   `tfsdk:"email_domains"`
+	URLs          types.Object `tfsdk:"urls"`
+}
+
+var organizationAttributeTypes = map[string]attr.Type{
+	"id":             types.StringType,
+	"title":          types.StringType,
+	"type":           types.StringType,
+	"use_case":       types.StringType,
+	"community_type": types.StringType,
+	"email_domains":  types.ListType{ElemType: types.StringType},
+	"urls":           types.ObjectType{AttrTypes: organizationURLsAttributeTypes},
+}
+
+var organizationURLsAttributeTypes = map[string]attr.Type{
+	"location": types.StringType,
+	"app":      types.StringType,
+}
+
+// parseOrganization merges an organization from GitBook into a Terraform model.
+func (m *organizationModel) parseOrganization(organization *gitbook.Organization, diags *diag.Diagnostics) {
+	m.ID = types.StringValue(organization.Id)
+	m.Title = types.StringValue(organization.Title)
+	m.Type = types.StringValue(string(organization.Type))
+	m.UseCase = types.StringPointerValue((*string)(organization.UseCase))
+	m.CommunityType = types.StringPointerValue((*string)(organization.CommunityType))
+
+	emailDomains := make([]attr.Value, len(organization.EmailDomains))
+	for i, emailDomain := range organization.EmailDomains {
+		emailDomains[i] = types.StringValue(emailDomain)
+	}
+	emailDomainsValue, d := types.ListValue(types.StringType, emailDomains)
+	if d.HasError() {
+		diags.Append(d...)
+		return
+	}
+	m.EmailDomains = emailDomainsValue
+
+	urls, d := types.ObjectValue(organizationURLsAttributeTypes, map[string]attr.Value{
+		"location": types.StringValue(organization.Urls.Location),
+		"app":      types.StringValue(organization.Urls.App),
+	})
+	if d.HasError() {
+		diags.Append(d...)
+		return
+	}
+	m.URLs = urls
+}
+
+// listOrganizations lists every organization the GitBook API token can
+// access, paging through the results of the GitBook API.
+func listOrganizations(ctx context.Context, client *gitbook.OrganizationsApiService) ([]gitbook.Organization, error) {
+	var organizations []gitbook.Organization
+	page := ""
+	for {
+		listReq := client.ListOrganizationsForAuthenticatedUser(ctx)
+		if page != "" {
+			listReq = listReq.Page(page)
+		}
+		list, _, err := listReq.Execute()
+		if err != nil {
+			return nil, err
+		}
+		organizations = append(organizations, list.Items...)
+
+		if list.Next == nil || list.Next.Page == "" {
+			return organizations, nil
+		}
+		page = list.Next.Page
+	}
+}
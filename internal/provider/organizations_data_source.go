@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	gitbook "github.com/GitbookIO/go-gitbook/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func NewOrganizationsDataSource() datasource.DataSource {
+	return &organizationsDataSource{}
+}
+
+// organizationsDataSource defines the data source implementation.
+type organizationsDataSource struct {
+	client *gitbook.OrganizationsApiService
+}
+
+type organizationsDataSourceModel struct {
+	Organizations types.List `tfsdk:"organizations"`
+}
+
+func (d *organizationsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organizations"
+}
+
+func (d *organizationsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the GitBook organizations that the provider's access token can access.",
+
+		Attributes: map[string]schema.Attribute{
+			"organizations": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The organizations, in the order returned by GitBook.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the organization.",
+						},
+						"title": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the organization.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The type of the organization, either `business` or `community`.",
+						},
+						"use_case": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The use case of the organization, if any.",
+						},
+						"community_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The community type of the organization, if any.",
+						},
+						"email_domains": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The email domains of the organization.",
+						},
+						"urls": schema.SingleNestedAttribute{
+							Computed: true,
+							Attributes: map[string]schema.Attribute{
+								"location": schema.StringAttribute{
+									Computed:            true,
+									MarkdownDescription: "The URL of the organization in the API.",
+								},
+								"app": schema.StringAttribute{
+									Computed:            true,
+									MarkdownDescription: "The URL of the organization in the GitBook app.",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *organizationsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*gitBookClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *gitBookClient, got: %T. Please report this issue to GitBook.", req.ProviderData),
+		)
+
+		return
+	}
+
+	client.addTokenError(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.client = client.OrganizationsApi
+}
+
+func (d *organizationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model organizationsDataSourceModel
+
+	// List the organizations via the GitBook API, paging through the results.
+	organizations, err := listOrganizations(ctx, d.client)
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error listing GitBook organizations",
+			"Could not list the GitBook organizations of the access token",
+			err,
+		)
+		return
+	}
+
+	values := make([]attr.Value, 0, len(organizations))
+	for _, organization := range organizations {
+		var organizationModel organizationModel
+		organizationModel.parseOrganization(&organization, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		value, diags := types.ObjectValueFrom(ctx, organizationAttributeTypes, organizationModel)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		values = append(values, value)
+	}
+
+	organizationsValue, diags := types.ListValue(types.ObjectType{AttrTypes: organizationAttributeTypes}, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	model.Organizations = organizationsValue
+
+	// Save data into Terraform state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
@@ -2,18 +2,21 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	gitbook "github.com/GitbookIO/go-gitbook/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -33,6 +36,42 @@ type gitBookProviderModel struct {
 	APIBaseURL     types.String `tfsdk:"base_url"`
 	IntegrationURL types.String `tfsdk:"integration_url"`
 	AccessToken    types.String `tfsdk:"access_token"`
+	BatchSize      types.Int64  `tfsdk:"batch_size"`
+	BatchDelay     types.String `tfsdk:"batch_delay"`
+}
+
+// gitBookClient is the data passed to resources and data sources when the
+// provider is configured.
+type gitBookClient struct {
+	*gitbook.APIClient
+
+	// entityBatcher coalesces entity writes, shared by all resources.
+	entityBatcher *entityBatcher
+
+	// token is the short-lived installation token used by the API client,
+	// kept to introspect the installation it was issued for.
+	token string
+	// tokenErr is the error obtaining the installation token when configuring
+	// the provider. It's reported by every resource and data source but the
+	// health data source, which reports it as a failed check instead.
+	tokenErr error
+	// tokenExchange obtains a new short-lived installation token, to check
+	// that the integration access token is still valid.
+	tokenExchange func(ctx context.Context) (string, error)
+}
+
+// addTokenError adds the error obtaining the installation token when
+// configuring the provider, if any.
+func (c *gitBookClient) addTokenError(diags *diag.Diagnostics) {
+	if c.tokenErr == nil {
+		return
+	}
+	var exchangeErr *tokenExchangeError
+	if errors.As(c.tokenErr, &exchangeErr) {
+		diags.AddError(exchangeErr.Summary, exchangeErr.Detail)
+	} else {
+		diags.AddError("Unable to obtain short-lived GitBook API access token", c.tokenErr.Error())
+	}
 }
 
 type integrationTokenEnvelope struct {
@@ -72,6 +111,19 @@ func (p *gitBookProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"batch_size": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of entity writes sent to GitBook in a single API call (env variable: `GITBOOK_BATCH_SIZE`). " +
+					fmt.Sprintf("Defaults to `%d`. Set to `1` to disable batching.", defaultEntityBatchSize),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"batch_delay": schema.StringAttribute{
+				MarkdownDescription: "Duration to wait for more entity writes of the same organization and type before sending them to GitBook, " +
+					fmt.Sprintf("e.g. `250ms` (env variable: `GITBOOK_BATCH_DELAY`). Defaults to `%s`.", defaultEntityBatchDelay),
+				Optional: true,
+			},
 		},
 	}
 }
@@ -105,6 +157,24 @@ func (p *gitBookProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
+	if config.BatchSize.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("batch_size"),
+			"Unknown GitBook batch size",
+			"The provider cannot configure the batching of entity writes as there is an unknown configuration value for the batch size. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the GITBOOK_BATCH_SIZE environment variable.",
+		)
+	}
+
+	if config.BatchDelay.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("batch_delay"),
+			"Unknown GitBook batch delay",
+			"The provider cannot configure the batching of entity writes as there is an unknown configuration value for the batch delay. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the GITBOOK_BATCH_DELAY environment variable.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -131,6 +201,44 @@ func (p *gitBookProvider) Configure(ctx context.Context, req provider.ConfigureR
 		accessToken = config.AccessToken.ValueString()
 	}
 
+	batchSize := defaultEntityBatchSize
+	if env := os.Getenv("GITBOOK_BATCH_SIZE"); env != "" && config.BatchSize.IsNull() {
+		size, err := strconv.Atoi(env)
+		if err != nil || size < 1 {
+			resp.Diagnostics.AddError(
+				"Invalid GitBook batch size",
+				fmt.Sprintf("The GITBOOK_BATCH_SIZE environment variable must be a positive integer, got: %q.", env),
+			)
+		}
+		batchSize = size
+	}
+	if !config.BatchSize.IsNull() {
+		batchSize = int(config.BatchSize.ValueInt64())
+	}
+
+	batchDelay := defaultEntityBatchDelay
+	if env := os.Getenv("GITBOOK_BATCH_DELAY"); env != "" && config.BatchDelay.IsNull() {
+		delay, err := time.ParseDuration(env)
+		if err != nil || delay < 0 {
+			resp.Diagnostics.AddError(
+				"Invalid GitBook batch delay",
+				fmt.Sprintf("The GITBOOK_BATCH_DELAY environment variable must be a non-negative duration such as `250ms`, got: %q.", env),
+			)
+		}
+		batchDelay = delay
+	}
+	if !config.BatchDelay.IsNull() {
+		delay, err := time.ParseDuration(config.BatchDelay.ValueString())
+		if err != nil || delay < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("batch_delay"),
+				"Invalid GitBook batch delay",
+				fmt.Sprintf("The batch delay must be a non-negative duration such as `250ms`, got: %q.", config.BatchDelay.ValueString()),
+			)
+		}
+		batchDelay = delay
+	}
+
 	if accessToken == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("access_token"),
@@ -146,59 +254,11 @@ func (p *gitBookProvider) Configure(ctx context.Context, req provider.ConfigureR
 	}
 
 	// Obtain a short-lived (installation scoped) GitBook API access token,
-	// using the long-lived integration token that.
-	tokenReq, err := http.NewRequestWithContext(ctx, http.MethodGet, integrationURL, nil)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to create HTTP request to obtain GitBook API access token",
-			"An unexpected error occurred when constructing an HTTP request to obtain a short-lived GitBook API access token. "+
-				"If the error is not clear, please contact GitBook support.\n\n"+
-				"GitBook Terraform integration HTTP error: "+err.Error(),
-		)
-		return
-	}
-
-	tokenReq.Header.Set("Authorization", "Bearer "+accessToken)
-	tokenReq.Header.Set("User-Agent", userAgent)
-	tokenResp, err := http.DefaultClient.Do(tokenReq)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to obtain short-lived GitBook API access token",
-			"An unexpected error occurred when obtaining a short-lived GitBook API access token. "+
-				"If the error is not clear, please contact GitBook support.\n\n"+
-				"GitBook Terraform integration HTTP error: "+err.Error(),
-		)
-		return
-	}
-	if tokenResp.StatusCode == http.StatusForbidden {
-		resp.Diagnostics.AddError(
-			"Invalid integration access token used",
-			"The provided GitBook Terraform integration access token is invalid. "+
-				"Visit the Terraform integration configuration on gitbook.com to obtain an access token.",
-		)
-		return
-	} else if tokenResp.StatusCode != http.StatusOK {
-		errBody, _ := io.ReadAll(tokenResp.Body)
-		resp.Diagnostics.AddError(
-			"Unable to obtain short-lived GitBook API access token",
-			"An unexpected HTTP response was received when obtaining a short-lived GitBook API access token. "+
-				"If the error is not clear, please contact GitBook support.\n\n"+
-				fmt.Sprintf(`Status: %q. Body: %q`, tokenResp.Status, errBody),
-		)
-		return
-	}
-
-	var tokenEnvelope integrationTokenEnvelope
-	err = json.NewDecoder(tokenResp.Body).Decode(&tokenEnvelope)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to parse token response from Terraform integration",
-			"An unexpected error occurred parsing the HTTP response data from the Terraform integration. "+
-				"If the error persists, please contact GitBook support.\n\n"+
-				"Parsing error: "+err.Error(),
-		)
-		return
-
+	// using the long-lived integration token. A failure is deferred to the
+	// resources and data sources, so that the health data source can report it.
+	token, tokenErr := exchangeIntegrationToken(ctx, integrationURL, accessToken, userAgent)
+	if tokenErr != nil {
+		tflog.Warn(ctx, "Unable to obtain short-lived GitBook API access token", map[string]interface{}{"error": tokenErr.Error()})
 	}
 
 	clientConfig := gitbook.NewConfiguration()
@@ -213,12 +273,21 @@ func (p *gitBookProvider) Configure(ctx context.Context, req provider.ConfigureR
 	// Using a custom default header simplifies usage of the client, as we don't
 	// have to explicitly set a `gitbook.ContextAccessToken` context value for
 	// each call.
-	clientConfig.AddDefaultHeader("Authorization", "Bearer "+tokenEnvelope.Token)
+	clientConfig.AddDefaultHeader("Authorization", "Bearer "+token)
 	clientConfig.UserAgent = userAgent
 
 	tflog.Debug(ctx, fmt.Sprintf("%+v", clientConfig))
 
-	client := gitbook.NewAPIClient(clientConfig)
+	apiClient := gitbook.NewAPIClient(clientConfig)
+	client := &gitBookClient{
+		APIClient:     apiClient,
+		entityBatcher: newEntityBatcher(apiClient.OrganizationsApi, batchSize, batchDelay),
+		token:         token,
+		tokenErr:      tokenErr,
+		tokenExchange: func(ctx context.Context) (string, error) {
+			return exchangeIntegrationToken(ctx, integrationURL, accessToken, userAgent)
+		},
+	}
 
 	resp.DataSourceData = client
 	resp.ResourceData = client
@@ -228,12 +297,21 @@ func (p *gitBookProvider) Resources(ctx context.Context) []func() resource.Resou
 	return []func() resource.Resource{
 		NewEntityResource,
 		NewEntitySchemaResource,
+		NewSpaceResource,
 	}
 }
 
 func (p *gitBookProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewEntityDataSource,
+		NewEntitiesDataSource,
+		NewEntityGraphDataSource,
+		NewInstallationDataSource,
+		NewHealthDataSource,
 		NewEntitySchemaDataSource,
+		NewEntitySchemasDataSource,
+		NewOrganizationDataSource,
+		NewOrganizationsDataSource,
+		NewEntitySchemaJSONDataSource,
 	}
 }
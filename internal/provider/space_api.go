@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	gitbook "github.com/GitbookIO/go-gitbook/api"
+)
+
+// The GitBook API client doesn't expose the emoji of spaces, nor the endpoints
+// to update their title or emoji, move them to another collection, or delete
+// them. These requests are made with the configuration of the API client
+// instead, until the client supports them.
+
+// space is a GitBook space, with the attributes not exposed by the GitBook API
+// client.
+type space struct {
+	gitbook.Space
+
+	// Unicode codepoint or character of the emoji of the space.
+	Emoji *string `json:"emoji,omitempty"`
+}
+
+// spaceUpdate is the body of a request to update a space. Unset attributes are
+// left unchanged.
+type spaceUpdate struct {
+	Title      *string                    `json:"title,omitempty"`
+	Emoji      *string                    `json:"emoji,omitempty"`
+	Visibility *gitbook.ContentVisibility `json:"visibility,omitempty"`
+}
+
+// spaceMove is the body of a request to move a space. A null parent moves the
+// space to the root of its organization.
+type spaceMove struct {
+	Parent *string `json:"parent"`
+}
+
+// spaceAPIError is an error response of the GitBook API to a request made
+// without the GitBook API client, like the errors of the client.
+type spaceAPIError struct {
+	status string
+	body   []byte
+}
+
+func (e *spaceAPIError) Error() string {
+	return e.status
+}
+
+func (e *spaceAPIError) Body() []byte {
+	return e.body
+}
+
+func getSpace(ctx context.Context, client *gitbook.APIClient, spaceID string) (*space, error) {
+	var result space
+	err := doSpaceRequest(ctx, client, http.MethodGet, "/spaces/"+url.PathEscape(spaceID), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func updateSpace(ctx context.Context, client *gitbook.APIClient, spaceID string, update spaceUpdate) error {
+	return doSpaceRequest(ctx, client, http.MethodPatch, "/spaces/"+url.PathEscape(spaceID), update, nil)
+}
+
+func moveSpace(ctx context.Context, client *gitbook.APIClient, spaceID string, parent *string) error {
+	return doSpaceRequest(ctx, client, http.MethodPost, "/spaces/"+url.PathEscape(spaceID)+"/move", spaceMove{Parent: parent}, nil)
+}
+
+func deleteSpace(ctx context.Context, client *gitbook.APIClient, spaceID string) error {
+	return doSpaceRequest(ctx, client, http.MethodDelete, "/spaces/"+url.PathEscape(spaceID), nil, nil)
+}
+
+// doSpaceRequest sends a request to the GitBook API, with the server, headers
+// and HTTP client of the GitBook API client. The JSON response is decoded into
+// `result`, if not nil.
+func doSpaceRequest(ctx context.Context, client *gitbook.APIClient, method, path string, body, result interface{}) error {
+	cfg := client.GetConfig()
+	serverURL, err := cfg.ServerURLWithContext(ctx, "SpacesApiService.GetSpaceById")
+	if err != nil {
+		return err
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, serverURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+	for name, value := range cfg.DefaultHeader {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("User-Agent", cfg.UserAgent)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := cfg.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return &spaceAPIError{status: resp.Status, body: respBody}
+	}
+
+	if result == nil || len(respBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(respBody, result)
+}
@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	gitbook "github.com/GitbookIO/go-gitbook/api"
+)
+
+// fakeSpaceRequest is a request received by `fakeSpaceAPI`.
+type fakeSpaceRequest struct {
+	Method      string
+	Path        string
+	ContentType string
+	Accept      string
+	UserAgent   string
+	Body        string
+}
+
+// fakeSpaceResponse is a response served by `fakeSpaceAPI`.
+type fakeSpaceResponse struct {
+	Status      int
+	ContentType string
+	Body        string
+}
+
+// fakeSpaceAPI serves the spaces of the GitBook API, recording the requests.
+type fakeSpaceAPI struct {
+	mu sync.Mutex
+	// responses are the responses by method and escaped path, e.g.
+	// `GET /spaces/space-1`. Other requests are answered with a 404.
+	responses map[string]fakeSpaceResponse
+	requests  []fakeSpaceRequest
+}
+
+func (api *fakeSpaceAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	api.requests = append(api.requests, fakeSpaceRequest{
+		Method:      r.Method,
+		Path:        r.URL.EscapedPath(),
+		ContentType: r.Header.Get("Content-Type"),
+		Accept:      r.Header.Get("Accept"),
+		UserAgent:   r.Header.Get("User-Agent"),
+		Body:        string(body),
+	})
+
+	response, ok := api.responses[r.Method+" "+r.URL.EscapedPath()]
+	if !ok {
+		response = fakeSpaceResponse{Status: http.StatusNotFound, Body: `{"error":{"code":404,"message":"Not found"}}`}
+	}
+	if response.ContentType == "" {
+		response.ContentType = "application/json"
+	}
+	w.Header().Set("Content-Type", response.ContentType)
+	w.WriteHeader(response.Status)
+	_, _ = io.WriteString(w, response.Body)
+}
+
+func TestSpaceRequests(t *testing.T) {
+	title := "Docs"
+	visibility := gitbook.CONTENTVISIBILITY_PUBLIC
+	parent := "collection-1"
+
+	tests := map[string]struct {
+		do       func(ctx context.Context, client *gitbook.APIClient) error
+		response fakeSpaceResponse
+		want     fakeSpaceRequest
+	}{
+		"get": {
+			do: func(ctx context.Context, client *gitbook.APIClient) error {
+				_, err := getSpace(ctx, client, "space 1")
+				return err
+			},
+			response: fakeSpaceResponse{Status: http.StatusOK, Body: `{"id":"space 1"}`},
+			want:     fakeSpaceRequest{Method: http.MethodGet, Path: "/spaces/space%201"},
+		},
+		"update": {
+			do: func(ctx context.Context, client *gitbook.APIClient) error {
+				return updateSpace(ctx, client, "space 1", spaceUpdate{Title: &title, Visibility: &visibility})
+			},
+			response: fakeSpaceResponse{Status: http.StatusNoContent},
+			want: fakeSpaceRequest{
+				Method:      http.MethodPatch,
+				Path:        "/spaces/space%201",
+				ContentType: "application/json",
+				Body:        `{"title":"Docs","visibility":"public"}`,
+			},
+		},
+		"move to collection": {
+			do: func(ctx context.Context, client *gitbook.APIClient) error {
+				return moveSpace(ctx, client, "space 1", &parent)
+			},
+			response: fakeSpaceResponse{Status: http.StatusNoContent},
+			want: fakeSpaceRequest{
+				Method:      http.MethodPost,
+				Path:        "/spaces/space%201/move",
+				ContentType: "application/json",
+				Body:        `{"parent":"collection-1"}`,
+			},
+		},
+		"move to organization": {
+			do: func(ctx context.Context, client *gitbook.APIClient) error {
+				return moveSpace(ctx, client, "space 1", nil)
+			},
+			response: fakeSpaceResponse{Status: http.StatusNoContent},
+			want: fakeSpaceRequest{
+				Method:      http.MethodPost,
+				Path:        "/spaces/space%201/move",
+				ContentType: "application/json",
+				Body:        `{"parent":null}`,
+			},
+		},
+		"delete": {
+			do: func(ctx context.Context, client *gitbook.APIClient) error {
+				return deleteSpace(ctx, client, "space 1")
+			},
+			response: fakeSpaceResponse{Status: http.StatusNoContent},
+			want:     fakeSpaceRequest{Method: http.MethodDelete, Path: "/spaces/space%201"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			api := &fakeSpaceAPI{responses: map[string]fakeSpaceResponse{
+				test.want.Method + " " + test.want.Path: test.response,
+			}}
+			client := newTestAPIClient(t, api)
+			if err := test.do(context.Background(), client); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			want := test.want
+			want.Accept = "application/json"
+			want.UserAgent = client.GetConfig().UserAgent
+			if len(api.requests) != 1 || api.requests[0] != want {
+				t.Errorf("expected request %+v, got %+v", want, api.requests)
+			}
+		})
+	}
+}
+
+func TestGetSpace(t *testing.T) {
+	api := &fakeSpaceAPI{responses: map[string]fakeSpaceResponse{
+		"GET /spaces/space-1": {
+			Status: http.StatusOK,
+			Body:   `{"object":"space","id":"space-1","title":"Docs","emoji":"1f4da","visibility":"public","organization":"org","parent":"collection-1","urls":{"location":"https://api.gitbook.com/v1/spaces/space-1","app":"https://app.gitbook.com/s/space-1"}}`,
+		},
+	}}
+
+	space, err := getSpace(context.Background(), newTestAPIClient(t, api), "space-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if space.Id != "space-1" || space.Title != "Docs" || space.Visibility != gitbook.CONTENTVISIBILITY_PUBLIC {
+		t.Errorf("unexpected space %+v", space.Space)
+	}
+	if space.Emoji == nil || *space.Emoji != "1f4da" {
+		t.Errorf("expected emoji %q, got %v", "1f4da", space.Emoji)
+	}
+	if space.Parent == nil || *space.Parent != "collection-1" {
+		t.Errorf("expected parent %q, got %v", "collection-1", space.Parent)
+	}
+}
+
+func TestSpaceRequestErrors(t *testing.T) {
+	tests := map[string]struct {
+		response fakeSpaceResponse
+		want     apiError
+	}{
+		"not found": {
+			response: fakeSpaceResponse{Status: http.StatusNotFound, Body: `{"error":{"code":404,"message":"Space not found","requestId":"req_1"}}`},
+			want:     apiError{Status: 404, Code: 404, Message: "Space not found", RequestID: "req_1", Kind: apiErrorKindNotFound},
+		},
+		"validation": {
+			response: fakeSpaceResponse{Status: http.StatusBadRequest, Body: `{"error":{"code":400,"message":"Invalid value for property \"title\""}}`},
+			want:     apiError{Status: 400, Code: 400, Message: `Invalid value for property "title"`, Kind: apiErrorKindValidation, Property: "title"},
+		},
+		// Unlike the errors of the GitBook API client, the status is kept when
+		// the body isn't JSON.
+		"not JSON": {
+			response: fakeSpaceResponse{Status: http.StatusBadGateway, ContentType: "text/html", Body: "Bad gateway\n"},
+			want:     apiError{Status: 502, Message: "Bad gateway", Kind: apiErrorKindServer},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			api := &fakeSpaceAPI{responses: map[string]fakeSpaceResponse{
+				"PATCH /spaces/space-1": test.response,
+			}}
+			err := updateSpace(context.Background(), newTestAPIClient(t, api), "space-1", spaceUpdate{})
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got := parseAPIError(err); *got != test.want {
+				t.Errorf("expected %+v, got %+v", test.want, *got)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type spaceModel struct {
+	ID             types.String `tfsdk:"id"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+	Title          types.String `tfsdk:"title"`
+	Emoji          types.String `tfsdk:"emoji"`
+	Visibility     types.String `tfsdk:"visibility"`
+	Parent         types.String `tfsdk:"parent"`
+	URLs           types.Object `tfsdk:"urls"`
+}
+
+var spaceURLsAttributeTypes = map[string]attr.Type{
+	"location":  types.StringType,
+	"app":       types.StringType,
+	"published": types.StringType,
+}
+
+// parseSpace merges a space from GitBook into a Terraform model.
+func (m *spaceModel) parseSpace(space *space, diags *diag.Diagnostics) {
+	m.ID = types.StringValue(space.Id)
+	if space.Organization != nil {
+		m.OrganizationID = types.StringValue(*space.Organization)
+	}
+	m.Title = types.StringValue(space.Title)
+	m.Emoji = types.StringPointerValue(space.Emoji)
+	m.Visibility = types.StringValue(string(space.Visibility))
+	m.Parent = types.StringPointerValue(space.Parent)
+
+	urls, d := types.ObjectValue(spaceURLsAttributeTypes, map[string]attr.Value{
+		"location":  types.StringValue(space.Urls.Location),
+		"app":       types.StringValue(space.Urls.App),
+		"published": types.StringPointerValue(space.Urls.Published),
+	})
+	if d.HasError() {
+		diags.Append(d...)
+		return
+	}
+	m.URLs = urls
+}
@@ -0,0 +1,328 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gitbook "github.com/GitbookIO/go-gitbook/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+func NewSpaceResource() resource.Resource {
+	return &spaceResource{}
+}
+
+type spaceResource struct {
+	client *gitbook.APIClient
+}
+
+func (r *spaceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space"
+}
+
+func (r *spaceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	visibilities := make([]string, len(gitbook.AllowedContentVisibilityEnumValues))
+	for i, visibility := range gitbook.AllowedContentVisibilityEnumValues {
+		visibilities[i] = string(visibility)
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Space resource. Spaces hold the content of GitBook, in an organization or one of its collections.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the space.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the organization that owns the space. Changing it creates a new space.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"title": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title of the space.",
+			},
+			"emoji": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The emoji of the space, as a Unicode character or codepoint. When not set, the emoji of the space is left unchanged.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"visibility": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: fmt.Sprintf("The visibility of the space, one of: `%s`. ", strings.Join(visibilities, "`, `")) +
+					"When not set, the visibility of the space is left unchanged.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(visibilities...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"parent": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The ID of the collection containing the space. When not set, the space is at the root of the organization.",
+			},
+			"urls": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"location": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The URL of the space in the API.",
+					},
+					"app": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The URL of the space in the GitBook app.",
+					},
+					"published": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The URL of the published space, null when the space is private.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *spaceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*gitBookClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *gitBookClient, got: %T. Please report this issue to GitBook.", req.ProviderData),
+		)
+
+		return
+	}
+
+	client.addTokenError(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = client.APIClient
+}
+
+func (r *spaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model *spaceModel
+
+	// Read Terraform plan data into the model.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationID := model.OrganizationID.ValueString()
+
+	createSpace := gitbook.RequestCreateSpace{
+		Title:  model.Title.ValueStringPointer(),
+		Parent: model.Parent.ValueStringPointer(),
+	}
+	if !model.Emoji.IsUnknown() {
+		createSpace.Emoji = model.Emoji.ValueStringPointer()
+	}
+
+	// Create space via the GitBook API.
+	created, _, err := r.client.SpacesApi.CreateSpace(ctx, organizationID).RequestCreateSpace(createSpace).Execute()
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error creating GitBook space",
+			"Could not create GitBook space",
+			err,
+		)
+		return
+	}
+
+	// Save the ID right away, so that the space is tracked even if setting
+	// its visibility fails.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), created.Id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The visibility can't be set when creating a space.
+	if !model.Visibility.IsUnknown() && model.Visibility.ValueString() != string(created.Visibility) {
+		visibility := gitbook.ContentVisibility(model.Visibility.ValueString())
+		err = updateSpace(ctx, r.client, created.Id, spaceUpdate{Visibility: &visibility})
+		if err != nil {
+			addAPIError(
+				&resp.Diagnostics,
+				"Error updating GitBook space",
+				fmt.Sprintf("Could not set the visibility of created GitBook space %q", created.Id),
+				err,
+			)
+			return
+		}
+	}
+
+	space, err := getSpace(ctx, r.client, created.Id)
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error reading created GitBook space",
+			"Could not read created GitBook space",
+			err,
+		)
+		return
+	}
+
+	model.parseSpace(space, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *spaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state.
+	state := &spaceModel{}
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	space, err := getSpace(ctx, r.client, state.ID.ValueString())
+	if isNotFoundError(err) || (err == nil && space.DeletedAt != nil) {
+		// The space was deleted outside of Terraform, so remove it from state
+		// to have it recreated.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error reading GitBook space",
+			"Could not read GitBook space",
+			err,
+		)
+		return
+	}
+
+	state.parseSpace(space, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *spaceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model, state *spaceModel
+
+	// Read Terraform plan and prior state data into the models.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spaceID := state.ID.ValueString()
+
+	// Only send the attributes that changed.
+	var update spaceUpdate
+	if !model.Title.Equal(state.Title) {
+		update.Title = model.Title.ValueStringPointer()
+	}
+	if !model.Emoji.IsUnknown() && !model.Emoji.IsNull() && !model.Emoji.Equal(state.Emoji) {
+		update.Emoji = model.Emoji.ValueStringPointer()
+	}
+	if !model.Visibility.IsUnknown() && !model.Visibility.Equal(state.Visibility) {
+		visibility := gitbook.ContentVisibility(model.Visibility.ValueString())
+		update.Visibility = &visibility
+	}
+	if update != (spaceUpdate{}) {
+		err := updateSpace(ctx, r.client, spaceID, update)
+		if err != nil {
+			addAPIError(
+				&resp.Diagnostics,
+				"Error updating GitBook space",
+				"Could not update GitBook space",
+				err,
+			)
+			return
+		}
+	}
+
+	if !model.Parent.Equal(state.Parent) {
+		err := moveSpace(ctx, r.client, spaceID, model.Parent.ValueStringPointer())
+		if err != nil {
+			addAPIError(
+				&resp.Diagnostics,
+				"Error moving GitBook space",
+				"Could not move GitBook space to its parent collection",
+				err,
+			)
+			return
+		}
+	}
+
+	space, err := getSpace(ctx, r.client, spaceID)
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error reading updated GitBook space",
+			"Could not read updated GitBook space",
+			err,
+		)
+		return
+	}
+
+	model.parseSpace(space, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *spaceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model spaceModel
+
+	// Read Terraform state into the model.
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := deleteSpace(ctx, r.client, model.ID.ValueString())
+	if err != nil && !isNotFoundError(err) {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error deleting GitBook space",
+			"Could not delete GitBook space",
+			err,
+		)
+	}
+}
+
+// ImportState imports a space using its ID. The organization and the other
+// attributes are read from GitBook.
+func (r *spaceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
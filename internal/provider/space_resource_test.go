@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testCreatedSpace = `{"object":"space","id":"space-1","title":"Docs","visibility":"private","organization":"org","urls":{"location":"https://api.gitbook.com/v1/spaces/space-1","app":"https://app.gitbook.com/s/space-1"}}`
+
+// newTestPlannedSpaceModel returns the planned model of a space of organization
+// `org`, with the given visibility.
+func newTestPlannedSpaceModel(visibility string) spaceModel {
+	return spaceModel{
+		ID:             types.StringUnknown(),
+		OrganizationID: types.StringValue("org"),
+		Title:          types.StringValue("Docs"),
+		Emoji:          types.StringUnknown(),
+		Visibility:     types.StringValue(visibility),
+		Parent:         types.StringNull(),
+		URLs:           types.ObjectUnknown(spaceURLsAttributeTypes),
+	}
+}
+
+func TestSpaceResourceCreate(t *testing.T) {
+	ctx := context.Background()
+	api := &fakeSpaceAPI{responses: map[string]fakeSpaceResponse{
+		"POST /orgs/org/spaces": {Status: http.StatusOK, Body: testCreatedSpace},
+		"PATCH /spaces/space-1": {Status: http.StatusNoContent},
+		"GET /spaces/space-1":   {Status: http.StatusOK, Body: `{"object":"space","id":"space-1","title":"Docs","emoji":"1f4da","visibility":"public","organization":"org","urls":{"location":"https://api.gitbook.com/v1/spaces/space-1","app":"https://app.gitbook.com/s/space-1"}}`},
+	}}
+	r := &spaceResource{client: newTestAPIClient(t, api)}
+
+	req := resource.CreateRequest{Plan: newTestResourcePlan(t, r, newTestPlannedSpaceModel("public"))}
+	resp := resource.CreateResponse{State: newTestNullResourceState(r)}
+	r.Create(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	// The visibility is set once the space is created.
+	if len(api.requests) != 3 || api.requests[1].Method != http.MethodPatch || api.requests[1].Body != `{"visibility":"public"}` {
+		t.Fatalf("expected the visibility of the created space to be updated, got %+v", api.requests)
+	}
+
+	var model spaceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("reading state: %v", resp.Diagnostics)
+	}
+	if model.ID.ValueString() != "space-1" || model.Visibility.ValueString() != "public" || model.Emoji.ValueString() != "1f4da" {
+		t.Errorf("unexpected state %+v", model)
+	}
+}
+
+func TestSpaceResourceCreateVisibilityError(t *testing.T) {
+	ctx := context.Background()
+	api := &fakeSpaceAPI{responses: map[string]fakeSpaceResponse{
+		"POST /orgs/org/spaces": {Status: http.StatusOK, Body: testCreatedSpace},
+		"PATCH /spaces/space-1": {Status: http.StatusForbidden, Body: `{"error":{"code":403,"message":"Forbidden"}}`},
+	}}
+	r := &spaceResource{client: newTestAPIClient(t, api)}
+
+	req := resource.CreateRequest{Plan: newTestResourcePlan(t, r, newTestPlannedSpaceModel("public"))}
+	resp := resource.CreateResponse{State: newTestNullResourceState(r)}
+	r.Create(ctx, req, &resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatalf("expected an error setting the visibility")
+	}
+	if got := resp.Diagnostics.Errors()[0].Summary(); got != "Error updating GitBook space" {
+		t.Errorf("expected the visibility update to fail, got %q", got)
+	}
+	if len(api.requests) != 2 {
+		t.Errorf("expected the space not to be read after the failed update, got %+v", api.requests)
+	}
+
+	// The space must be tracked, for Terraform to mark it as tainted.
+	var id types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	if !id.Equal(types.StringValue("space-1")) {
+		t.Errorf("expected the state to track space %q, got %s", "space-1", id)
+	}
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// upgradeStateFixture upgrades the raw state JSON of a fixture with the state
+// upgrader of the given version, decoding it with the prior schema like the
+// framework does.
+func upgradeStateFixture(t *testing.T, r resource.ResourceWithUpgradeState, version int64, fixture string) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	rawJSON, err := os.ReadFile(fixture)
+	if err != nil {
+		t.Fatalf("reading fixture: %s", err)
+	}
+
+	upgrader, ok := r.UpgradeState(ctx)[version]
+	if !ok {
+		t.Fatalf("no state upgrader for version %d", version)
+	}
+
+	rawState := &tfprotov6.RawState{JSON: rawJSON}
+	priorValue, err := rawState.UnmarshalWithOpts(upgrader.PriorSchema.Type().TerraformType(ctx), tfprotov6.UnmarshalOpts{
+		ValueFromJSONOpts: tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true},
+	})
+	if err != nil {
+		t.Fatalf("decoding fixture with the prior schema: %s", err)
+	}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	req := resource.UpgradeStateRequest{
+		RawState: rawState,
+		State:    &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: priorValue},
+	}
+	resp := resource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+	upgrader.StateUpgrader(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("upgrading state: %v", resp.Diagnostics)
+	}
+	return resp.State
+}